@@ -1,6 +1,6 @@
 module github.com/vulkan-go/demos
 
-go 1.15
+go 1.17
 
 require (
 	github.com/go-gl/glfw/v3.3/glfw v0.0.0-20210311203641-62640a716d48
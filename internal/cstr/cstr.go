@@ -0,0 +1,23 @@
+// Package cstr prepares the strings passed to Vulkan by the vulkan package,
+// which expects them null-terminated.
+package cstr
+
+import "strings"
+
+// String returns s with the terminating null byte, adding it if missing.
+func String(s string) string {
+	if !strings.HasSuffix(s, "\x00") {
+		s += "\x00"
+	}
+	return s
+}
+
+// Strings makes sure that every string is null-terminated,
+// as windowing libraries return extension names without the terminator.
+func Strings(list []string) []string {
+	out := make([]string, 0, len(list))
+	for _, s := range list {
+		out = append(out, String(s))
+	}
+	return out
+}
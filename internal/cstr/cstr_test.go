@@ -0,0 +1,17 @@
+package cstr
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStrings(t *testing.T) {
+	got := Strings([]string{"VK_KHR_surface", "VK_KHR_xcb_surface\x00", ""})
+	want := []string{"VK_KHR_surface\x00", "VK_KHR_xcb_surface\x00", "\x00"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Strings = %q, want %q", got, want)
+	}
+	if got := Strings(nil); len(got) != 0 {
+		t.Errorf("Strings(nil) = %q, want empty", got)
+	}
+}
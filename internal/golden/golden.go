@@ -0,0 +1,121 @@
+// Package golden compares rendered frames with reference PNG images,
+// it's shared by the golden-image tests of the demos.
+package golden
+
+import (
+	"flag"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+var update = flag.Bool("update", false, "update the reference images")
+
+var (
+	initOnce sync.Once
+	initErr  error
+)
+
+// InitVulkan loads the Vulkan loader once per test binary,
+// the test is skipped when there is no loader installed.
+func InitVulkan(t testing.TB) {
+	t.Helper()
+	initOnce.Do(func() {
+		if initErr = vk.SetDefaultGetInstanceProcAddr(); initErr != nil {
+			return
+		}
+		initErr = vk.Init()
+	})
+	if initErr != nil {
+		t.Skipf("no Vulkan loader: %s", initErr)
+	}
+}
+
+// Compare counts the pixels having a channel that differs by more than tolerance,
+// the diff image shows them in red over a faded copy of want.
+func Compare(got, want image.Image, tolerance uint8) (mismatched int, diff *image.RGBA) {
+	bounds := want.Bounds()
+	diff = image.NewRGBA(bounds)
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			g := color.RGBAModel.Convert(got.At(x, y)).(color.RGBA)
+			w := color.RGBAModel.Convert(want.At(x, y)).(color.RGBA)
+			if channelDiff(g.R, w.R) > tolerance || channelDiff(g.G, w.G) > tolerance ||
+				channelDiff(g.B, w.B) > tolerance || channelDiff(g.A, w.A) > tolerance {
+				mismatched++
+				diff.SetRGBA(x, y, color.RGBA{R: 255, A: 255})
+				continue
+			}
+			gray := uint8((uint32(w.R) + uint32(w.G) + uint32(w.B)) / 3 / 4)
+			diff.SetRGBA(x, y, color.RGBA{R: gray, G: gray, B: gray, A: 255})
+		}
+	}
+	return mismatched, diff
+}
+
+func channelDiff(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+// Check compares img with the reference PNG, every channel may differ by up to tolerance
+// to allow for rounding differences between drivers. On failure the rendered image and the diff
+// are written next to the reference as name.got.png and name.diff.png.
+// Run go test -update to replace the reference with img.
+func Check(t testing.TB, img image.Image, path string, tolerance uint8) {
+	t.Helper()
+	if *update {
+		if err := writePNG(path, img); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := readPNG(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds() != want.Bounds() {
+		t.Fatalf("image size %v differs from %s size %v", img.Bounds().Size(), path, want.Bounds().Size())
+	}
+	mismatched, diff := Compare(img, want, tolerance)
+	if mismatched == 0 {
+		return
+	}
+	base := strings.TrimSuffix(path, ".png")
+	if err := writePNG(base+".got.png", img); err != nil {
+		t.Error(err)
+	}
+	if err := writePNG(base+".diff.png", diff); err != nil {
+		t.Error(err)
+	}
+	t.Errorf("%d pixels differ from %s by more than %d, see %s.diff.png; run go test -update to accept the changes",
+		mismatched, path, tolerance, base)
+}
+
+func readPNG(path string) (image.Image, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return png.Decode(f)
+}
+
+func writePNG(path string, img image.Image) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,32 @@
+package golden
+
+import (
+	"image"
+	"image/color"
+	"testing"
+)
+
+func TestCompare(t *testing.T) {
+	want := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	got := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for i := range want.Pix {
+		want.Pix[i] = 100
+		got.Pix[i] = 100
+	}
+	got.SetRGBA(1, 1, color.RGBA{R: 102, G: 98, B: 100, A: 100}) // within tolerance
+	got.SetRGBA(2, 3, color.RGBA{R: 100, G: 100, B: 110, A: 100})
+
+	mismatched, diff := Compare(got, want, 2)
+	if mismatched != 1 {
+		t.Errorf("mismatched = %d, want 1", mismatched)
+	}
+	if c := diff.RGBAAt(2, 3); c != (color.RGBA{R: 255, A: 255}) {
+		t.Errorf("diff at (2, 3) = %v, want red", c)
+	}
+	if c := diff.RGBAAt(1, 1); c.R != c.G {
+		t.Errorf("diff at (1, 1) = %v, want gray", c)
+	}
+	if mismatched, _ := Compare(got, want, 10); mismatched != 0 {
+		t.Errorf("mismatched = %d with tolerance 10, want 0", mismatched)
+	}
+}
@@ -0,0 +1,37 @@
+// Package vkproc resolves the Vulkan entry points the vulkan package doesn't bind,
+// it's shared by the demos calling them through cgo.
+package vkproc
+
+/*
+#include <stdlib.h>
+
+typedef void (*vkVoidFunction)(void);
+typedef vkVoidFunction (*vkGetInstanceProcAddrFunc)(void* instance, const char* name);
+
+// The loader entry point resolved by the vulkan package, it is weak
+// so builds without it (e.g. Android) still link and resolve nothing.
+extern vkGetInstanceProcAddrFunc vgo_vkGetInstanceProcAddr __attribute__((weak));
+
+static vkVoidFunction getInstanceProc(void* instance, const char* name) {
+	if (&vgo_vkGetInstanceProcAddr == NULL || vgo_vkGetInstanceProcAddr == NULL) {
+		return NULL;
+	}
+	return vgo_vkGetInstanceProcAddr(instance, name);
+}
+*/
+import "C"
+
+import (
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// InstanceProc returns the entry point of the instance command, or of the global command
+// if instance is nil. It returns nil if the command is not available, e.g. its extension
+// is not enabled or the vulkan package has not loaded vkGetInstanceProcAddr.
+func InstanceProc(instance vk.Instance, name string) unsafe.Pointer {
+	cname := C.CString(name)
+	defer C.free(unsafe.Pointer(cname))
+	return unsafe.Pointer(C.getInstanceProc(unsafe.Pointer(instance), cname))
+}
@@ -0,0 +1,9 @@
+package vkproc
+
+import "testing"
+
+func TestInstanceProcUnknown(t *testing.T) {
+	if fn := InstanceProc(nil, "vkNoSuchCommand"); fn != nil {
+		t.Errorf("InstanceProc(nil, %q) = %p, want nil", "vkNoSuchCommand", fn)
+	}
+}
@@ -0,0 +1,313 @@
+package vulkandraw
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+
+typedef void (*vkVoidFunction)(void);
+
+// The leading members of VkDebugUtilsMessengerCallbackDataEXT.
+typedef struct debugUtilsCallbackData {
+	uint32_t    sType;
+	const void* pNext;
+	uint32_t    flags;
+	const char* pMessageIdName;
+	int32_t     messageIdNumber;
+	const char* pMessage;
+} debugUtilsCallbackData;
+
+typedef uint32_t (*debugUtilsCallbackFunc)(uint32_t severity, uint32_t types,
+	const debugUtilsCallbackData* data, void* userData);
+
+typedef struct debugUtilsMessengerCreateInfo {
+	uint32_t               sType;
+	const void*            pNext;
+	uint32_t               flags;
+	uint32_t               messageSeverity;
+	uint32_t               messageType;
+	debugUtilsCallbackFunc pfnUserCallback;
+	void*                  pUserData;
+} debugUtilsMessengerCreateInfo;
+
+extern uint32_t goDebugUtilsMessage(uint32_t severity, uint32_t types, char* idName, char* message,
+	void* userData);
+
+static uint32_t debugUtilsCallback(uint32_t severity, uint32_t types,
+	const debugUtilsCallbackData* data, void* userData) {
+	return goDebugUtilsMessage(severity, types, (char*)data->pMessageIdName, (char*)data->pMessage,
+		userData);
+}
+
+static int32_t createDebugUtilsMessenger(vkVoidFunction create, void* instance, uint32_t severity,
+	void* userData, uint64_t* messenger) {
+	typedef int32_t (*createFunc)(void*, const debugUtilsMessengerCreateInfo*, const void*, uint64_t*);
+	createFunc fn = (createFunc)create;
+	if (fn == NULL) {
+		return -7; // VK_ERROR_EXTENSION_NOT_PRESENT
+	}
+	debugUtilsMessengerCreateInfo info = {
+		.sType = 1000128004, // VK_STRUCTURE_TYPE_DEBUG_UTILS_MESSENGER_CREATE_INFO_EXT
+		.messageSeverity = severity,
+		.messageType = 0x7, // general, validation and performance
+		.pfnUserCallback = debugUtilsCallback,
+		.pUserData = userData,
+	};
+	return fn(instance, &info, NULL, messenger);
+}
+
+static void destroyDebugUtilsMessenger(vkVoidFunction destroy, void* instance, uint64_t messenger) {
+	typedef void (*destroyFunc)(void*, uint64_t, const void*);
+	destroyFunc fn = (destroyFunc)destroy;
+	if (fn != NULL) {
+		fn(instance, messenger, NULL);
+	}
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"runtime/cgo"
+	"unsafe"
+
+	"github.com/vulkan-go/demos/internal/vkproc"
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// Severity of a validation or debug message, the zero value is not a severity
+// but lets DeviceOptions.Severity default to DebugSeverity.
+type Severity int
+
+const (
+	SeverityVerbose Severity = iota + 1
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+)
+
+func (s Severity) String() string {
+	switch s {
+	case SeverityVerbose:
+		return "VERBOSE"
+	case SeverityInfo:
+		return "INFO"
+	case SeverityWarning:
+		return "WARN"
+	default:
+		return "ERROR"
+	}
+}
+
+// Logger receives the messages of the validation layers.
+type Logger interface {
+	Log(severity Severity, message string)
+}
+
+// LoggerFunc adapts a function to the Logger interface.
+type LoggerFunc func(severity Severity, message string)
+
+func (f LoggerFunc) Log(severity Severity, message string) {
+	f(severity, message)
+}
+
+var (
+	// EnableValidation turns on the validation layers and the debug messenger,
+	// it is set by the VULKANDRAW_VALIDATION environment variable unless set explicitly.
+	// It is disabled by default since the layers are not guaranteed to be present,
+	// e.g. they must be bundled into the APK on Android.
+	EnableValidation = len(os.Getenv("VULKANDRAW_VALIDATION")) > 0
+
+	// DebugLogger receives the validation messages of at least DebugSeverity,
+	// they are the defaults of DeviceOptions.Logger and DeviceOptions.Severity.
+	DebugLogger Logger = LoggerFunc(func(severity Severity, message string) {
+		log.Printf("[%s] %s", severity, message)
+	})
+	DebugSeverity = SeverityWarning
+)
+
+// validationLayers are tried in order, the first available one is enabled.
+// The individual LunarG and Google layers are what older SDKs and Android NDKs ship,
+// all of the available ones are enabled then.
+var validationLayers = []string{
+	"VK_LAYER_KHRONOS_validation",
+	"VK_LAYER_LUNARG_standard_validation",
+}
+
+var legacyValidationLayers = []string{
+	"VK_LAYER_GOOGLE_threading",
+	"VK_LAYER_LUNARG_parameter_validation",
+	"VK_LAYER_LUNARG_object_tracker",
+	"VK_LAYER_LUNARG_core_validation",
+	"VK_LAYER_GOOGLE_unique_objects",
+}
+
+// selectValidationLayers picks the validation layers out of the available ones,
+// the names are returned null-terminated.
+func selectValidationLayers(available []string) []string {
+	for _, name := range validationLayers {
+		if hasExtension(available, name) {
+			return []string{name + "\x00"}
+		}
+	}
+	var layers []string
+	for _, name := range legacyValidationLayers {
+		if hasExtension(available, name) {
+			layers = append(layers, name+"\x00")
+		}
+	}
+	return layers
+}
+
+func getInstanceLayers() (layerNames []string) {
+	var instanceLayerLen uint32
+	ret := vk.EnumerateInstanceLayerProperties(&instanceLayerLen, nil)
+	check(ret, "vk.EnumerateInstanceLayerProperties")
+	instanceLayers := make([]vk.LayerProperties, instanceLayerLen)
+	ret = vk.EnumerateInstanceLayerProperties(&instanceLayerLen, instanceLayers)
+	check(ret, "vk.EnumerateInstanceLayerProperties")
+	for _, layer := range instanceLayers {
+		layer.Deref()
+		layerNames = append(layerNames, vk.ToString(layer.LayerName[:]))
+	}
+	return layerNames
+}
+
+func getDeviceLayers(gpu vk.PhysicalDevice) (layerNames []string) {
+	var deviceLayerLen uint32
+	ret := vk.EnumerateDeviceLayerProperties(gpu, &deviceLayerLen, nil)
+	check(ret, "vk.EnumerateDeviceLayerProperties")
+	deviceLayers := make([]vk.LayerProperties, deviceLayerLen)
+	ret = vk.EnumerateDeviceLayerProperties(gpu, &deviceLayerLen, deviceLayers)
+	check(ret, "vk.EnumerateDeviceLayerProperties")
+	for _, layer := range deviceLayers {
+		layer.Deref()
+		layerNames = append(layerNames, vk.ToString(layer.LayerName[:]))
+	}
+	return layerNames
+}
+
+// debugSink is where a messenger delivers the messages of at least its severity.
+type debugSink struct {
+	logger   Logger
+	severity Severity
+}
+
+// newSinkHandle stores a cgo.Handle of the sink in C memory, it's passed to the callbacks
+// as their user data so that each messenger delivers to the logger of its own device.
+func newSinkHandle(sink *debugSink) unsafe.Pointer {
+	p := (*C.uintptr_t)(C.malloc(C.size_t(unsafe.Sizeof(C.uintptr_t(0)))))
+	*p = C.uintptr_t(cgo.NewHandle(sink))
+	return unsafe.Pointer(p)
+}
+
+func deleteSinkHandle(userData unsafe.Pointer) {
+	cgo.Handle(*(*C.uintptr_t)(userData)).Delete()
+	C.free(userData)
+}
+
+// logMessage delivers a validation message to the sink of the messenger's user data,
+// the message is only formatted if its severity is high enough.
+func logMessage(userData unsafe.Pointer, severity Severity, format string, args ...interface{}) {
+	sink := &debugSink{logger: DebugLogger, severity: DebugSeverity}
+	if userData != nil {
+		sink = cgo.Handle(*(*C.uintptr_t)(userData)).Value().(*debugSink)
+	}
+	if severity >= sink.severity {
+		sink.logger.Log(severity, fmt.Sprintf(format, args...))
+	}
+}
+
+// createDebugMessenger routes the validation messages of at least the severity to the logger,
+// DebugSeverity and DebugLogger are used if they're unset. It uses VK_EXT_debug_utils
+// if the instance has it enabled, otherwise the deprecated VK_EXT_debug_report.
+func (v *VulkanDeviceInfo) createDebugMessenger(debugUtils bool, logger Logger, minSeverity Severity) error {
+	if logger == nil {
+		logger = DebugLogger
+	}
+	if minSeverity == 0 {
+		minSeverity = DebugSeverity
+	}
+	userData := newSinkHandle(&debugSink{logger: logger, severity: minSeverity})
+	if debugUtils {
+		severity := C.uint32_t(0)
+		for s := minSeverity; s <= SeverityError; s++ {
+			// verbose 0x1, info 0x10, warning 0x100, error 0x1000
+			severity |= 1 << (4 * C.uint32_t(s-SeverityVerbose))
+		}
+		var messenger C.uint64_t
+		create := C.vkVoidFunction(vkproc.InstanceProc(v.Instance, "vkCreateDebugUtilsMessengerEXT"))
+		ret := vk.Result(C.createDebugUtilsMessenger(create, unsafe.Pointer(v.Instance), severity, userData, &messenger))
+		if err := vk.Error(ret); err != nil {
+			deleteSinkHandle(userData)
+			err = fmt.Errorf("vkCreateDebugUtilsMessengerEXT failed with %s", err)
+			return err
+		}
+		v.messenger = uint64(messenger)
+		v.sinkHandle = userData
+		return nil
+	}
+
+	flags := vk.DebugReportErrorBit
+	if minSeverity <= SeverityWarning {
+		flags |= vk.DebugReportWarningBit | vk.DebugReportPerformanceWarningBit
+	}
+	if minSeverity <= SeverityInfo {
+		flags |= vk.DebugReportInformationBit
+	}
+	if minSeverity <= SeverityVerbose {
+		flags |= vk.DebugReportDebugBit
+	}
+	dbgCreateInfo := vk.DebugReportCallbackCreateInfo{
+		SType:       vk.StructureTypeDebugReportCallbackCreateInfo,
+		Flags:       vk.DebugReportFlags(flags),
+		PfnCallback: dbgCallbackFunc,
+		PUserData:   userData,
+	}
+	var dbg vk.DebugReportCallback
+	err := vk.Error(vk.CreateDebugReportCallback(v.Instance, &dbgCreateInfo, nil, &dbg))
+	if err != nil {
+		deleteSinkHandle(userData)
+		err = fmt.Errorf("vk.CreateDebugReportCallback failed with %s", err)
+		return err
+	}
+	v.dbg = dbg
+	v.sinkHandle = userData
+	return nil
+}
+
+func (v *VulkanDeviceInfo) destroyDebugMessenger() {
+	if v.messenger != 0 {
+		destroy := C.vkVoidFunction(vkproc.InstanceProc(v.Instance, "vkDestroyDebugUtilsMessengerEXT"))
+		C.destroyDebugUtilsMessenger(destroy, unsafe.Pointer(v.Instance), C.uint64_t(v.messenger))
+		v.messenger = 0
+	}
+	if v.dbg != vk.NullDebugReportCallback {
+		vk.DestroyDebugReportCallback(v.Instance, v.dbg, nil)
+		v.dbg = vk.NullDebugReportCallback
+	}
+	if v.sinkHandle != nil {
+		deleteSinkHandle(v.sinkHandle)
+		v.sinkHandle = nil
+	}
+}
+
+func dbgCallbackFunc(flags vk.DebugReportFlags, objectType vk.DebugReportObjectType,
+	object uint64, location uint, messageCode int32, pLayerPrefix string,
+	pMessage string, pUserData unsafe.Pointer) vk.Bool32 {
+
+	var severity Severity
+	switch {
+	case flags&vk.DebugReportFlags(vk.DebugReportErrorBit) != 0:
+		severity = SeverityError
+	case flags&vk.DebugReportFlags(vk.DebugReportWarningBit|vk.DebugReportPerformanceWarningBit) != 0:
+		severity = SeverityWarning
+	case flags&vk.DebugReportFlags(vk.DebugReportInformationBit) != 0:
+		severity = SeverityInfo
+	default:
+		severity = SeverityVerbose
+	}
+	logMessage(pUserData, severity, "%d: %s on layer %s", messageCode, pMessage, pLayerPrefix)
+	return vk.Bool32(vk.False)
+}
@@ -0,0 +1,27 @@
+package vulkandraw
+
+// #include <stdint.h>
+import "C"
+
+import "unsafe"
+
+//export goDebugUtilsMessage
+func goDebugUtilsMessage(severity, types C.uint32_t, idName, message *C.char, userData unsafe.Pointer) C.uint32_t {
+	var s Severity
+	switch {
+	case severity >= 0x1000:
+		s = SeverityError
+	case severity >= 0x100:
+		s = SeverityWarning
+	case severity >= 0x10:
+		s = SeverityInfo
+	default:
+		s = SeverityVerbose
+	}
+	var id string
+	if idName != nil {
+		id = C.GoString(idName)
+	}
+	logMessage(userData, s, "%s: %s", id, C.GoString(message))
+	return 0 // VK_FALSE, don't abort the call
+}
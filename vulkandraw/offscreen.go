@@ -0,0 +1,247 @@
+package vulkandraw
+
+import (
+	"fmt"
+	"log"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// OffscreenFormat is the format of the offscreen images, every implementation
+// supports it as a color attachment.
+const OffscreenFormat = vk.FormatR8g8b8a8Unorm
+
+// VulkanOffscreenInfo replaces VulkanSwapchainInfo on headless devices,
+// the frames are rendered into device images in turn and never presented.
+type VulkanOffscreenInfo struct {
+	Device vk.Device
+
+	DisplaySize   vk.Extent2D
+	DisplayFormat vk.Format
+
+	Images       []vk.Image
+	memory       []vk.DeviceMemory
+	Framebuffers []vk.Framebuffer
+	DisplayViews []vk.ImageView
+
+	// Current is the index of the image the last frame has been rendered into.
+	Current uint32
+	next    uint32
+	readback
+}
+
+// ImagesLen is the offscreen counterpart of VulkanSwapchainInfo.DefaultSwapchainLen.
+func (o *VulkanOffscreenInfo) ImagesLen() uint32 {
+	return uint32(len(o.Images))
+}
+
+// CreateOffscreen creates imageCount images of the given size to render into instead of a swapchain.
+func (v *VulkanDeviceInfo) CreateOffscreen(width, height, imageCount uint32) (VulkanOffscreenInfo, error) {
+	if width == 0 || height == 0 || imageCount == 0 {
+		err := fmt.Errorf("CreateOffscreen: %d images of %dx%d, none of them can be zero",
+			imageCount, width, height)
+		return VulkanOffscreenInfo{}, err
+	}
+	o := VulkanOffscreenInfo{
+		Device: v.Device,
+		DisplaySize: vk.Extent2D{
+			Width:  width,
+			Height: height,
+		},
+		DisplayFormat: OffscreenFormat,
+		Images:        make([]vk.Image, imageCount),
+		memory:        make([]vk.DeviceMemory, imageCount),
+		DisplayViews:  make([]vk.ImageView, imageCount),
+		readback:      v.newReadback(),
+	}
+	for i := range o.Images {
+		// Phase 1: vk.CreateImage
+		//			the image is also a transfer source so it can be read back
+
+		imageCreateInfo := vk.ImageCreateInfo{
+			SType:     vk.StructureTypeImageCreateInfo,
+			ImageType: vk.ImageType2d,
+			Format:    o.DisplayFormat,
+			Extent: vk.Extent3D{
+				Width:  width,
+				Height: height,
+				Depth:  1,
+			},
+			MipLevels:     1,
+			ArrayLayers:   1,
+			Samples:       vk.SampleCount1Bit,
+			Tiling:        vk.ImageTilingOptimal,
+			Usage:         vk.ImageUsageFlags(vk.ImageUsageColorAttachmentBit | vk.ImageUsageTransferSrcBit),
+			SharingMode:   vk.SharingModeExclusive,
+			InitialLayout: vk.ImageLayoutUndefined,
+		}
+		err := vk.Error(vk.CreateImage(v.Device, &imageCreateInfo, nil, &o.Images[i]))
+		if err != nil {
+			err = fmt.Errorf("vk.CreateImage failed with %s", err)
+			return o, err
+		}
+
+		// Phase 2: vk.GetImageMemoryRequirements
+		//			vk.AllocateMemory
+		//			vk.BindImageMemory
+
+		var memReq vk.MemoryRequirements
+		vk.GetImageMemoryRequirements(v.Device, o.Images[i], &memReq)
+		memReq.Deref()
+		allocInfo := vk.MemoryAllocateInfo{
+			SType:          vk.StructureTypeMemoryAllocateInfo,
+			AllocationSize: memReq.Size,
+		}
+		var ok bool
+		allocInfo.MemoryTypeIndex, ok = vk.FindMemoryTypeIndex(v.gpu, memReq.MemoryTypeBits,
+			vk.MemoryPropertyDeviceLocalBit)
+		if !ok {
+			err = fmt.Errorf("vk.FindMemoryTypeIndex: no device local memory for offscreen images")
+			return o, err
+		}
+		err = vk.Error(vk.AllocateMemory(v.Device, &allocInfo, nil, &o.memory[i]))
+		if err != nil {
+			err = fmt.Errorf("vk.AllocateMemory failed with %s", err)
+			return o, err
+		}
+		err = vk.Error(vk.BindImageMemory(v.Device, o.Images[i], o.memory[i], 0))
+		if err != nil {
+			err = fmt.Errorf("vk.BindImageMemory failed with %s", err)
+			return o, err
+		}
+
+		// Phase 3: vk.CreateImageView
+
+		viewCreateInfo := vk.ImageViewCreateInfo{
+			SType:    vk.StructureTypeImageViewCreateInfo,
+			Image:    o.Images[i],
+			ViewType: vk.ImageViewType2d,
+			Format:   o.DisplayFormat,
+			Components: vk.ComponentMapping{
+				R: vk.ComponentSwizzleR,
+				G: vk.ComponentSwizzleG,
+				B: vk.ComponentSwizzleB,
+				A: vk.ComponentSwizzleA,
+			},
+			SubresourceRange: vk.ImageSubresourceRange{
+				AspectMask: vk.ImageAspectFlags(vk.ImageAspectColorBit),
+				LevelCount: 1,
+				LayerCount: 1,
+			},
+		}
+		err = vk.Error(vk.CreateImageView(v.Device, &viewCreateInfo, nil, &o.DisplayViews[i]))
+		if err != nil {
+			err = fmt.Errorf("vk.CreateImageView failed with %s", err)
+			return o, err
+		}
+	}
+	return o, nil
+}
+
+func (o *VulkanOffscreenInfo) CreateFramebuffers(renderPass vk.RenderPass) error {
+	o.Framebuffers = make([]vk.Framebuffer, len(o.DisplayViews))
+	for i := range o.Framebuffers {
+		fbCreateInfo := vk.FramebufferCreateInfo{
+			SType:           vk.StructureTypeFramebufferCreateInfo,
+			RenderPass:      renderPass,
+			Layers:          1,
+			AttachmentCount: 1,
+			PAttachments:    o.DisplayViews[i : i+1],
+			Width:           o.DisplaySize.Width,
+			Height:          o.DisplaySize.Height,
+		}
+		err := vk.Error(vk.CreateFramebuffer(o.Device, &fbCreateInfo, nil, &o.Framebuffers[i]))
+		if err != nil {
+			err = fmt.Errorf("vk.CreateFramebuffer failed with %s", err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *VulkanOffscreenInfo) Destroy() {
+	for i := range o.Framebuffers {
+		vk.DestroyFramebuffer(o.Device, o.Framebuffers[i], nil)
+	}
+	for i := range o.DisplayViews {
+		vk.DestroyImageView(o.Device, o.DisplayViews[i], nil)
+	}
+	for i := range o.Images {
+		vk.DestroyImage(o.Device, o.Images[i], nil)
+		vk.FreeMemory(o.Device, o.memory[i], nil)
+	}
+	o.Framebuffers = nil
+	o.DisplayViews = nil
+	o.Images = nil
+	o.memory = nil
+}
+
+// VulkanInitOffscreen is the offscreen counterpart of VulkanInit.
+func VulkanInitOffscreen(v *VulkanDeviceInfo, o *VulkanOffscreenInfo,
+	r *VulkanRenderInfo, b *VulkanBufferInfo, gfx *VulkanGfxPipelineInfo) {
+
+	r.recordCommandBuffers(o.Framebuffers, o.DisplaySize, b, gfx)
+	r.createSyncObjects()
+}
+
+// VulkanDrawOffscreenFrame renders a frame into the next offscreen image and sets o.Current,
+// like VulkanDrawFrame it only blocks when r.FramesInFlight frames are still being rendered.
+func VulkanDrawOffscreenFrame(v *VulkanDeviceInfo,
+	o *VulkanOffscreenInfo, r *VulkanRenderInfo) bool {
+	frame := r.frame
+	frameFences := r.fences[frame : frame+1]
+
+	// Phase 1: vk.WaitForFences
+
+	const timeoutNano = 10 * 1000 * 1000 * 1000 // 10 sec
+	err := vk.Error(vk.WaitForFences(v.Device, 1, frameFences, vk.True, timeoutNano))
+	if err != nil {
+		err = fmt.Errorf("vk.WaitForFences failed with %s", err)
+		log.Println("[WARN]", err)
+		return false
+	}
+
+	// Phase 2: pick the next image
+	//			there is nothing to acquire, but the image may still be rendered by another frame
+
+	nextIdx := o.next
+	if imageFence := r.imageFences[nextIdx]; imageFence != vk.NullFence && imageFence != r.fences[frame] {
+		err = vk.Error(vk.WaitForFences(v.Device, 1, []vk.Fence{imageFence}, vk.True, timeoutNano))
+		if err != nil {
+			err = fmt.Errorf("vk.WaitForFences failed with %s", err)
+			log.Println("[WARN]", err)
+			return false
+		}
+	}
+	r.imageFences[nextIdx] = r.fences[frame]
+
+	// Phase 3: vk.QueueSubmit
+
+	vk.ResetFences(v.Device, 1, frameFences)
+	submitInfo := []vk.SubmitInfo{{
+		SType:              vk.StructureTypeSubmitInfo,
+		CommandBufferCount: 1,
+		PCommandBuffers:    r.cmdBuffers[nextIdx:],
+	}}
+	err = vk.Error(vk.QueueSubmit(v.Queue, 1, submitInfo, r.fences[frame]))
+	if err != nil {
+		r.restoreFrame(frame)
+		err = fmt.Errorf("vk.QueueSubmit failed with %s", err)
+		log.Println("[WARN]", err)
+		return false
+	}
+	r.frame = (frame + 1) % len(r.fences)
+	o.Current = nextIdx
+	o.rendered = true
+	o.layout = r.finalLayout
+	o.next = (nextIdx + 1) % o.ImagesLen()
+	return true
+}
+
+func DestroyOffscreenInOrder(v *VulkanDeviceInfo, o *VulkanOffscreenInfo,
+	r *VulkanRenderInfo, b *VulkanBufferInfo, gfx *VulkanGfxPipelineInfo) {
+
+	r.destroy(v)
+	o.Destroy()
+	v.destroy(b, gfx)
+}
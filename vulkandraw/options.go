@@ -0,0 +1,101 @@
+package vulkandraw
+
+import (
+	"reflect"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// DeviceOptions configures NewVulkanDevice. The zero value creates a headless device
+// on the physical device picked by the default policy, see selectPhysicalDevice.
+type DeviceOptions struct {
+	// InstanceExtensions and DeviceExtensions are enabled in addition to the ones vulkandraw
+	// needs itself, e.g. the surface extensions the window system requires. Devices lacking
+	// any of the device extensions are not considered.
+	InstanceExtensions []string
+	DeviceExtensions   []string
+
+	// Layers are enabled on the instance and, if the device reports them, on the device.
+	Layers []string
+
+	// Features are the physical device features to enable,
+	// devices lacking any of them are not considered.
+	Features *vk.PhysicalDeviceFeatures
+
+	// Device selects the physical device by its index (a number) or by a case-insensitive
+	// substring of its name, it defaults to DeviceOverride.
+	Device string
+
+	// PickDevice chooses one of the suitable physical devices and returns its index in candidates,
+	// or -1 if none of them will do. By default the device type decides, see selectPhysicalDevice.
+	// The candidates are already narrowed down by Device.
+	PickDevice func(candidates []DeviceCandidate) int
+
+	// CreateSurface creates the surface to present to. Without it the device is headless
+	// and renders offscreen only, see CreateOffscreen.
+	CreateSurface func(instance vk.Instance) (vk.Surface, error)
+
+	// Allocator is used to create and destroy the instance, the surface and the logical device
+	// only, CreateSurface must create the surface with the same allocator. The objects created
+	// later (the swapchain, offscreen images, buffers, render passes, pipelines, command pools
+	// and the debug messenger) use the default allocator of the driver.
+	Allocator *vk.AllocationCallbacks
+
+	// Validation enables the validation layers, it defaults to EnableValidation.
+	Validation bool
+
+	// Logger receives the validation messages instead of DebugLogger.
+	Logger Logger
+
+	// Severity is the lowest severity of the messages passed to Logger, it defaults to DebugSeverity.
+	Severity Severity
+}
+
+// DeviceCandidate is a physical device suitable for rendering, see DeviceOptions.PickDevice.
+type DeviceCandidate struct {
+	Index  int // in the order of vk.EnumeratePhysicalDevices
+	Name   string
+	Type   vk.PhysicalDeviceType
+	Device vk.PhysicalDevice
+
+	graphicsQueueFamily uint32
+	presentQueueFamily  uint32
+}
+
+// pickDeviceByType prefers the device types in this order: discrete, integrated, virtual, other and CPU.
+func pickDeviceByType(candidates []DeviceCandidate) int {
+	typeRank := map[vk.PhysicalDeviceType]int{
+		vk.PhysicalDeviceTypeDiscreteGpu:   4,
+		vk.PhysicalDeviceTypeIntegratedGpu: 3,
+		vk.PhysicalDeviceTypeVirtualGpu:    2,
+		vk.PhysicalDeviceTypeOther:         1,
+		vk.PhysicalDeviceTypeCpu:           0,
+	}
+	selected, selectedRank := -1, -1
+	for i, c := range candidates {
+		if rank := typeRank[c.Type]; rank > selectedRank {
+			selected, selectedRank = i, rank
+		}
+	}
+	return selected
+}
+
+// missingFeatures lists the required features the physical device doesn't support.
+func missingFeatures(gpu vk.PhysicalDevice, required *vk.PhysicalDeviceFeatures) []string {
+	var supported vk.PhysicalDeviceFeatures
+	vk.GetPhysicalDeviceFeatures(gpu, &supported)
+	supported.Deref()
+
+	var missing []string
+	have, want := reflect.ValueOf(&supported).Elem(), reflect.ValueOf(required).Elem()
+	rt := want.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		if rt.Field(i).Type != reflect.TypeOf(vk.Bool32(0)) {
+			continue
+		}
+		if want.Field(i).Uint() != 0 && have.Field(i).Uint() == 0 {
+			missing = append(missing, rt.Field(i).Name)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,364 @@
+package vulkandraw
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"unsafe"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// readback holds what's needed to copy a rendered image back to the host,
+// it's embedded into VulkanSwapchainInfo and VulkanOffscreenInfo.
+type readback struct {
+	enabled     bool
+	rendered    bool           // at least a frame has been rendered
+	layout      vk.ImageLayout // of the images after the render pass of the last frame
+	gpu         vk.PhysicalDevice
+	queue       vk.Queue
+	queueFamily uint32
+}
+
+func (v *VulkanDeviceInfo) newReadback() readback {
+	return readback{
+		enabled:     true,
+		gpu:         v.gpu,
+		queue:       v.Queue,
+		queueFamily: v.GraphicsQueueFamily,
+	}
+}
+
+// CaptureNextFrame makes the next VulkanDrawFrame copy its image to the host before presenting it,
+// ReadFramebuffer and Screenshot return that frame afterwards. The copy waits for the GPU,
+// so it's meant for screenshots and tests, not for every frame.
+func (s *VulkanSwapchainInfo) CaptureNextFrame() error {
+	if !s.enabled {
+		err := fmt.Errorf("CaptureNextFrame: the surface doesn't support copying from swapchain images")
+		return err
+	}
+	s.captureNext = true
+	s.captured, s.captureErr = nil, nil
+	return nil
+}
+
+// Capturing tells whether CaptureNextFrame has been called and no frame has been captured yet,
+// e.g. because VulkanDrawFrame has skipped the frame as the swapchain was out of date.
+func (s *VulkanSwapchainInfo) Capturing() bool {
+	return s.captureNext
+}
+
+// ReadFramebuffer returns the frame captured after CaptureNextFrame.
+// Presented images belong to the presentation engine, so they can't be read afterwards.
+func (s *VulkanSwapchainInfo) ReadFramebuffer() (*image.RGBA, error) {
+	if s.captureErr != nil {
+		return nil, s.captureErr
+	}
+	if s.captured == nil {
+		err := fmt.Errorf("ReadFramebuffer: no frame has been captured, see CaptureNextFrame")
+		return nil, err
+	}
+	return s.captured, nil
+}
+
+// Screenshot saves the frame captured after CaptureNextFrame as a PNG file.
+func (s *VulkanSwapchainInfo) Screenshot(path string) error {
+	return writePNG(path, s.ReadFramebuffer)
+}
+
+// capture copies the acquired image the frame has been rendered into, before it is presented.
+// The copy waits for the rendering to finish on the semaphore and signals it again for the present.
+func (s *VulkanSwapchainInfo) capture(img vk.Image, renderDone []vk.Semaphore) {
+	s.captureNext = false
+	c, err := s.newImageCopy(s.Device, img, s.DisplayFormat, s.DisplaySize, s.layout)
+	if err != nil {
+		s.captureErr = err
+		return
+	}
+	defer c.destroy()
+	if err := c.submit(s.queue, renderDone, renderDone); err != nil {
+		s.captureErr = err
+		return
+	}
+	s.captured, s.captureErr = c.read()
+}
+
+// ReadFramebuffer copies the offscreen image of the last frame to the host.
+// It waits for the device to be idle, so it's meant for screenshots and tests, not for every frame.
+func (o *VulkanOffscreenInfo) ReadFramebuffer() (*image.RGBA, error) {
+	if !o.rendered {
+		err := fmt.Errorf("ReadFramebuffer: no frame has been rendered yet")
+		return nil, err
+	}
+	err := vk.Error(vk.DeviceWaitIdle(o.Device))
+	if err != nil {
+		err = fmt.Errorf("vk.DeviceWaitIdle failed with %s", err)
+		return nil, err
+	}
+	c, err := o.newImageCopy(o.Device, o.Images[o.Current], o.DisplayFormat, o.DisplaySize, o.layout)
+	if err != nil {
+		return nil, err
+	}
+	defer c.destroy()
+	if err := c.submit(o.queue, nil, nil); err != nil {
+		return nil, err
+	}
+	return c.read()
+}
+
+// Screenshot saves the offscreen image of the last frame as a PNG file.
+func (o *VulkanOffscreenInfo) Screenshot(path string) error {
+	return writePNG(path, o.ReadFramebuffer)
+}
+
+func writePNG(path string, read func() (*image.RGBA, error)) error {
+	img, err := read()
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// imageCopy is a one-time command buffer copying an image into a host visible buffer.
+type imageCopy struct {
+	device  vk.Device
+	buffer  vk.Buffer
+	memory  vk.DeviceMemory
+	cmdPool vk.CommandPool
+	cmd     []vk.CommandBuffer
+
+	size    vk.Extent2D
+	swizzle bool
+}
+
+// newImageCopy records the copy of the image into a host visible buffer, the image is in
+// the final layout of the render pass and is transitioned back to it after the copy.
+// It must not be in use by the presentation engine when the copy is submitted.
+func (rb *readback) newImageCopy(device vk.Device, img vk.Image,
+	format vk.Format, size vk.Extent2D, layout vk.ImageLayout) (*imageCopy, error) {
+
+	c := &imageCopy{
+		device: device,
+		size:   size,
+	}
+	switch format {
+	case vk.FormatR8g8b8a8Unorm, vk.FormatR8g8b8a8Srgb:
+	case vk.FormatB8g8r8a8Unorm, vk.FormatB8g8r8a8Srgb:
+		c.swizzle = true
+	default:
+		err := fmt.Errorf("newImageCopy: unsupported format %d", format)
+		return nil, err
+	}
+
+	// Phase 1: vk.CreateBuffer
+	//			vk.AllocateMemory
+	//			create a host visible buffer to copy the pixels into
+
+	bufferCreateInfo := vk.BufferCreateInfo{
+		SType:       vk.StructureTypeBufferCreateInfo,
+		Size:        c.bufferSize(),
+		Usage:       vk.BufferUsageFlags(vk.BufferUsageTransferDstBit),
+		SharingMode: vk.SharingModeExclusive,
+	}
+	err := vk.Error(vk.CreateBuffer(device, &bufferCreateInfo, nil, &c.buffer))
+	if err != nil {
+		err = fmt.Errorf("vk.CreateBuffer failed with %s", err)
+		return nil, err
+	}
+
+	var memReq vk.MemoryRequirements
+	vk.GetBufferMemoryRequirements(device, c.buffer, &memReq)
+	memReq.Deref()
+	allocInfo := vk.MemoryAllocateInfo{
+		SType:          vk.StructureTypeMemoryAllocateInfo,
+		AllocationSize: memReq.Size,
+	}
+	var ok bool
+	allocInfo.MemoryTypeIndex, ok = vk.FindMemoryTypeIndex(rb.gpu, memReq.MemoryTypeBits,
+		vk.MemoryPropertyHostVisibleBit|vk.MemoryPropertyHostCoherentBit)
+	if !ok {
+		c.destroy()
+		err = fmt.Errorf("vk.FindMemoryTypeIndex: no host visible memory for readback")
+		return nil, err
+	}
+	err = vk.Error(vk.AllocateMemory(device, &allocInfo, nil, &c.memory))
+	if err != nil {
+		c.destroy()
+		err = fmt.Errorf("vk.AllocateMemory failed with %s", err)
+		return nil, err
+	}
+	err = vk.Error(vk.BindBufferMemory(device, c.buffer, c.memory, 0))
+	if err != nil {
+		c.destroy()
+		err = fmt.Errorf("vk.BindBufferMemory failed with %s", err)
+		return nil, err
+	}
+
+	// Phase 2: vk.CmdPipelineBarrier
+	//			vk.CmdCopyImageToBuffer
+	//			record the copy into a one-time command buffer
+
+	cmdPoolCreateInfo := vk.CommandPoolCreateInfo{
+		SType:            vk.StructureTypeCommandPoolCreateInfo,
+		Flags:            vk.CommandPoolCreateFlags(vk.CommandPoolCreateTransientBit),
+		QueueFamilyIndex: rb.queueFamily,
+	}
+	err = vk.Error(vk.CreateCommandPool(device, &cmdPoolCreateInfo, nil, &c.cmdPool))
+	if err != nil {
+		c.destroy()
+		err = fmt.Errorf("vk.CreateCommandPool failed with %s", err)
+		return nil, err
+	}
+	c.cmd = make([]vk.CommandBuffer, 1)
+	cmdBufferAllocateInfo := vk.CommandBufferAllocateInfo{
+		SType:              vk.StructureTypeCommandBufferAllocateInfo,
+		CommandPool:        c.cmdPool,
+		Level:              vk.CommandBufferLevelPrimary,
+		CommandBufferCount: 1,
+	}
+	err = vk.Error(vk.AllocateCommandBuffers(device, &cmdBufferAllocateInfo, c.cmd))
+	if err != nil {
+		c.destroy()
+		err = fmt.Errorf("vk.AllocateCommandBuffers failed with %s", err)
+		return nil, err
+	}
+	cmd := c.cmd[0]
+	cmdBufferBeginInfo := vk.CommandBufferBeginInfo{
+		SType: vk.StructureTypeCommandBufferBeginInfo,
+		Flags: vk.CommandBufferUsageFlags(vk.CommandBufferUsageOneTimeSubmitBit),
+	}
+	err = vk.Error(vk.BeginCommandBuffer(cmd, &cmdBufferBeginInfo))
+	if err != nil {
+		c.destroy()
+		err = fmt.Errorf("vk.BeginCommandBuffer failed with %s", err)
+		return nil, err
+	}
+	subresourceRange := vk.ImageSubresourceRange{
+		AspectMask: vk.ImageAspectFlags(vk.ImageAspectColorBit),
+		LevelCount: 1,
+		LayerCount: 1,
+	}
+	toTransfer := []vk.ImageMemoryBarrier{{
+		SType:               vk.StructureTypeImageMemoryBarrier,
+		SrcAccessMask:       vk.AccessFlags(vk.AccessColorAttachmentWriteBit),
+		DstAccessMask:       vk.AccessFlags(vk.AccessTransferReadBit),
+		OldLayout:           layout,
+		NewLayout:           vk.ImageLayoutTransferSrcOptimal,
+		SrcQueueFamilyIndex: vk.QueueFamilyIgnored,
+		DstQueueFamilyIndex: vk.QueueFamilyIgnored,
+		Image:               img,
+		SubresourceRange:    subresourceRange,
+	}}
+	vk.CmdPipelineBarrier(cmd,
+		vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit),
+		vk.PipelineStageFlags(vk.PipelineStageTransferBit),
+		0, 0, nil, 0, nil, 1, toTransfer)
+	regions := []vk.BufferImageCopy{{
+		ImageSubresource: vk.ImageSubresourceLayers{
+			AspectMask: vk.ImageAspectFlags(vk.ImageAspectColorBit),
+			LayerCount: 1,
+		},
+		ImageExtent: vk.Extent3D{
+			Width:  size.Width,
+			Height: size.Height,
+			Depth:  1,
+		},
+	}}
+	vk.CmdCopyImageToBuffer(cmd, img, vk.ImageLayoutTransferSrcOptimal, c.buffer, 1, regions)
+	fromTransfer := []vk.ImageMemoryBarrier{{
+		SType:               vk.StructureTypeImageMemoryBarrier,
+		SrcAccessMask:       vk.AccessFlags(vk.AccessTransferReadBit),
+		DstAccessMask:       vk.AccessFlags(vk.AccessColorAttachmentWriteBit),
+		OldLayout:           vk.ImageLayoutTransferSrcOptimal,
+		NewLayout:           layout,
+		SrcQueueFamilyIndex: vk.QueueFamilyIgnored,
+		DstQueueFamilyIndex: vk.QueueFamilyIgnored,
+		Image:               img,
+		SubresourceRange:    subresourceRange,
+	}}
+	vk.CmdPipelineBarrier(cmd,
+		vk.PipelineStageFlags(vk.PipelineStageTransferBit),
+		vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit),
+		0, 0, nil, 0, nil, 1, fromTransfer)
+	err = vk.Error(vk.EndCommandBuffer(cmd))
+	if err != nil {
+		c.destroy()
+		err = fmt.Errorf("vk.EndCommandBuffer failed with %s", err)
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *imageCopy) bufferSize() vk.DeviceSize {
+	return vk.DeviceSize(c.size.Width) * vk.DeviceSize(c.size.Height) * 4
+}
+
+// submit runs the copy on the queue and waits for it to finish. The copy waits for the wait
+// semaphores before the transfer and signals the signal semaphores when it's done.
+func (c *imageCopy) submit(queue vk.Queue, wait, signal []vk.Semaphore) error {
+	waitStages := make([]vk.PipelineStageFlags, len(wait))
+	for i := range waitStages {
+		waitStages[i] = vk.PipelineStageFlags(vk.PipelineStageTransferBit)
+	}
+	submitInfo := []vk.SubmitInfo{{
+		SType:                vk.StructureTypeSubmitInfo,
+		WaitSemaphoreCount:   uint32(len(wait)),
+		PWaitSemaphores:      wait,
+		PWaitDstStageMask:    waitStages,
+		CommandBufferCount:   1,
+		PCommandBuffers:      c.cmd,
+		SignalSemaphoreCount: uint32(len(signal)),
+		PSignalSemaphores:    signal,
+	}}
+	err := vk.Error(vk.QueueSubmit(queue, 1, submitInfo, vk.NullFence))
+	if err != nil {
+		err = fmt.Errorf("vk.QueueSubmit failed with %s", err)
+		return err
+	}
+	err = vk.Error(vk.QueueWaitIdle(queue))
+	if err != nil {
+		err = fmt.Errorf("vk.QueueWaitIdle failed with %s", err)
+		return err
+	}
+	return nil
+}
+
+// read copies the pixels out of the buffer, swapping red and blue for BGRA formats.
+func (c *imageCopy) read() (*image.RGBA, error) {
+	var data unsafe.Pointer
+	err := vk.Error(vk.MapMemory(c.device, c.memory, 0, c.bufferSize(), 0, &data))
+	if err != nil {
+		err = fmt.Errorf("vk.MapMemory failed with %s", err)
+		return nil, err
+	}
+	rgba := image.NewRGBA(image.Rect(0, 0, int(c.size.Width), int(c.size.Height)))
+	copy(rgba.Pix, unsafe.Slice((*byte)(data), len(rgba.Pix)))
+	vk.UnmapMemory(c.device, c.memory)
+	if c.swizzle {
+		for i := 0; i < len(rgba.Pix); i += 4 {
+			rgba.Pix[i], rgba.Pix[i+2] = rgba.Pix[i+2], rgba.Pix[i]
+		}
+	}
+	return rgba, nil
+}
+
+func (c *imageCopy) destroy() {
+	if c.cmdPool != vk.NullCommandPool {
+		vk.DestroyCommandPool(c.device, c.cmdPool, nil)
+	}
+	if c.memory != vk.NullDeviceMemory {
+		vk.FreeMemory(c.device, c.memory, nil)
+	}
+	if c.buffer != vk.NullBuffer {
+		vk.DestroyBuffer(c.device, c.buffer, nil)
+	}
+}
@@ -1,29 +1,51 @@
 package vulkandraw
 
 import (
+	"errors"
 	"fmt"
+	"image"
 	"log"
+	"os"
+	"strconv"
+	"strings"
 	"unsafe"
 
+	"github.com/vulkan-go/demos/internal/cstr"
 	vk "github.com/vulkan-go/vulkan"
 	"github.com/xlab/linmath"
 )
 
-// enableDebug is disabled by default since VK_EXT_debug_report
-// is not guaranteed to be present on a device.
-//
-// Nvidia Shield K1 fw 1.3.0 lacks this extension,
-// on fw 1.2.0 it works fine.
-const enableDebug = false
+// DeviceOverride selects the physical device by its index (a number) or by a case-insensitive
+// substring of its name instead of the default policy, see selectPhysicalDevice.
+// It is read from the VULKANDRAW_DEVICE environment variable unless set explicitly.
+var DeviceOverride = os.Getenv("VULKANDRAW_DEVICE")
+
+// ErrNoDevice is returned by NewVulkanDevice when there is no Vulkan driver
+// or none of the physical devices is suitable, use errors.Is to check for it.
+var ErrNoDevice = errors.New("no suitable physical device")
 
 type VulkanDeviceInfo struct {
 	gpuDevices []vk.PhysicalDevice
-
-	dbg      vk.DebugReportCallback
-	Instance vk.Instance
-	Surface  vk.Surface
-	Queue    vk.Queue
-	Device   vk.Device
+	gpu        vk.PhysicalDevice
+	allocator  *vk.AllocationCallbacks
+
+	// PhysicalDeviceIndex and PhysicalDeviceName record the selected physical device.
+	PhysicalDeviceIndex int
+	PhysicalDeviceName  string
+
+	dbg        vk.DebugReportCallback
+	messenger  uint64         // VkDebugUtilsMessengerEXT
+	sinkHandle unsafe.Pointer // the user data of dbg or messenger, see newSinkHandle
+	Instance   vk.Instance
+	Surface    vk.Surface
+	Device     vk.Device
+
+	// Queue is the graphics queue and PresentQueue is the queue
+	// presenting to Surface, they are the same if a family supports both.
+	Queue               vk.Queue
+	PresentQueue        vk.Queue
+	GraphicsQueueFamily uint32
+	PresentQueueFamily  uint32
 }
 
 type VulkanSwapchainInfo struct {
@@ -34,9 +56,29 @@ type VulkanSwapchainInfo struct {
 
 	DisplaySize   vk.Extent2D
 	DisplayFormat vk.Format
+	// Transform is the pre-transform of the swapchain, the current transform of the surface
+	// when it has been created, e.g. the rotation of an Android device. The presentation
+	// engine applies it, so the rendering is rotated in the opposite direction, see CreateBuffers.
+	Transform vk.SurfaceTransformFlagBits
 
+	Images       []vk.Image
 	Framebuffers []vk.Framebuffer
 	DisplayViews []vk.ImageView
+
+	// Current is the index of the image the last frame has been presented from.
+	Current uint32
+	readback
+	captureNext bool // see CaptureNextFrame
+	captured    *image.RGBA
+	captureErr  error
+
+	// OutOfDate is set by VulkanDrawFrame when the swapchain no longer matches the surface,
+	// e.g. after the window has been resized or rotated, see VulkanRecreate.
+	OutOfDate bool
+
+	// retired is set when a failed Recreate has handed the swapchain over to the driver,
+	// it can't be handed over again but must still be destroyed.
+	retired bool
 }
 
 func (v *VulkanSwapchainInfo) DefaultSwapchain() vk.Swapchain {
@@ -50,6 +92,7 @@ func (v *VulkanSwapchainInfo) DefaultSwapchainLen() uint32 {
 type VulkanBufferInfo struct {
 	device        vk.Device
 	vertexBuffers []vk.Buffer
+	memory        vk.DeviceMemory
 }
 
 func (v *VulkanBufferInfo) DefaultVertexBuffer() vk.Buffer {
@@ -64,27 +107,119 @@ type VulkanGfxPipelineInfo struct {
 	pipeline vk.Pipeline
 }
 
+// DefaultFramesInFlight is the number of frames the CPU may record ahead of the GPU
+// unless VulkanRenderInfo.FramesInFlight is set.
+const DefaultFramesInFlight = 2
+
 type VulkanRenderInfo struct {
 	device vk.Device
 
+	// FramesInFlight limits how many submitted frames may be rendered at once,
+	// it must be set before VulkanInit, zero means DefaultFramesInFlight.
+	FramesInFlight int
+
 	RenderPass vk.RenderPass
 	cmdPool    vk.CommandPool
 	cmdBuffers []vk.CommandBuffer
-	semaphores []vk.Semaphore
-	fences     []vk.Fence
+
+	finalLayout vk.ImageLayout // of the images after the render pass
+
+	// per frame in flight
+	semaphores       []vk.Semaphore // image available
+	renderSemaphores []vk.Semaphore // render finished
+	fences           []vk.Fence
+	frame            int
+
+	// per swapchain image, the fence of the frame that renders into it
+	imageFences []vk.Fence
 }
 
+// DefaultFence returns the fence of the current frame in flight.
 func (v *VulkanRenderInfo) DefaultFence() vk.Fence {
-	return v.fences[0]
+	return v.fences[v.frame]
 }
 
+// DefaultSemaphore returns the image available semaphore of the current frame in flight.
 func (v *VulkanRenderInfo) DefaultSemaphore() vk.Semaphore {
-	return v.semaphores[0]
+	return v.semaphores[v.frame]
 }
 
 func VulkanInit(v *VulkanDeviceInfo, s *VulkanSwapchainInfo,
 	r *VulkanRenderInfo, b *VulkanBufferInfo, gfx *VulkanGfxPipelineInfo) {
 
+	b.setTransform(s.Transform)
+	r.recordCommandBuffers(s.Framebuffers, s.DisplaySize, b, gfx)
+	r.createSyncObjects()
+}
+
+// createSyncObjects creates the fences and semaphores of the frames in flight,
+// the command buffers must have been allocated already.
+func (r *VulkanRenderInfo) createSyncObjects() {
+	// the fences start signaled so the first wait on each frame returns immediately
+	fenceCreateInfo := vk.FenceCreateInfo{
+		SType: vk.StructureTypeFenceCreateInfo,
+		Flags: vk.FenceCreateFlags(vk.FenceCreateSignaledBit),
+	}
+	semaphoreCreateInfo := vk.SemaphoreCreateInfo{
+		SType: vk.StructureTypeSemaphoreCreateInfo,
+	}
+	if r.FramesInFlight <= 0 {
+		r.FramesInFlight = DefaultFramesInFlight
+	}
+	r.fences = make([]vk.Fence, r.FramesInFlight)
+	r.semaphores = make([]vk.Semaphore, r.FramesInFlight)
+	r.renderSemaphores = make([]vk.Semaphore, r.FramesInFlight)
+	for i := 0; i < r.FramesInFlight; i++ {
+		ret := vk.CreateFence(r.device, &fenceCreateInfo, nil, &r.fences[i])
+		check(ret, "vk.CreateFence")
+		ret = vk.CreateSemaphore(r.device, &semaphoreCreateInfo, nil, &r.semaphores[i])
+		check(ret, "vk.CreateSemaphore")
+		ret = vk.CreateSemaphore(r.device, &semaphoreCreateInfo, nil, &r.renderSemaphores[i])
+		check(ret, "vk.CreateSemaphore")
+	}
+	r.frame = 0
+	r.imageFences = make([]vk.Fence, len(r.cmdBuffers))
+}
+
+// restoreFrame replaces the sync objects of the frame after its submission has failed:
+// the fence has been reset and the image available semaphore signaled, but no batch
+// is going to signal or wait on them, so the next use of the frame would hang.
+func (r *VulkanRenderInfo) restoreFrame(frame int) {
+	oldFence := r.fences[frame]
+	for i := range r.imageFences {
+		if r.imageFences[i] == oldFence {
+			r.imageFences[i] = vk.NullFence
+		}
+	}
+	vk.DestroyFence(r.device, oldFence, nil)
+	vk.DestroySemaphore(r.device, r.semaphores[frame], nil)
+	fenceCreateInfo := vk.FenceCreateInfo{
+		SType: vk.StructureTypeFenceCreateInfo,
+		Flags: vk.FenceCreateFlags(vk.FenceCreateSignaledBit),
+	}
+	semaphoreCreateInfo := vk.SemaphoreCreateInfo{
+		SType: vk.StructureTypeSemaphoreCreateInfo,
+	}
+	ret := vk.CreateFence(r.device, &fenceCreateInfo, nil, &r.fences[frame])
+	check(ret, "vk.CreateFence")
+	ret = vk.CreateSemaphore(r.device, &semaphoreCreateInfo, nil, &r.semaphores[frame])
+	check(ret, "vk.CreateSemaphore")
+}
+
+// VulkanRecreate rebuilds everything that depends on the surface size after VulkanDrawFrame
+// has marked the swapchain as out of date or the window has been resized.
+func VulkanRecreate(v *VulkanDeviceInfo, s *VulkanSwapchainInfo,
+	r *VulkanRenderInfo, b *VulkanBufferInfo, gfx *VulkanGfxPipelineInfo) error {
+
+	if err := s.Recreate(v, r.RenderPass); err != nil {
+		return err
+	}
+	return r.Recreate(s, b, gfx)
+}
+
+func (r *VulkanRenderInfo) recordCommandBuffers(framebuffers []vk.Framebuffer,
+	displaySize vk.Extent2D, b *VulkanBufferInfo, gfx *VulkanGfxPipelineInfo) {
+
 	clearValues := []vk.ClearValue{
 		vk.NewClearValue([]float32{0.098, 0.71, 0.996, 1}),
 	}
@@ -95,12 +230,12 @@ func VulkanInit(v *VulkanDeviceInfo, s *VulkanSwapchainInfo,
 		renderPassBeginInfo := vk.RenderPassBeginInfo{
 			SType:       vk.StructureTypeRenderPassBeginInfo,
 			RenderPass:  r.RenderPass,
-			Framebuffer: s.Framebuffers[i],
+			Framebuffer: framebuffers[i],
 			RenderArea: vk.Rect2D{
 				Offset: vk.Offset2D{
 					X: 0, Y: 0,
 				},
-				Extent: s.DisplaySize,
+				Extent: displaySize,
 			},
 			ClearValueCount: 1,
 			PClearValues:    clearValues,
@@ -118,74 +253,142 @@ func VulkanInit(v *VulkanDeviceInfo, s *VulkanSwapchainInfo,
 		ret = vk.EndCommandBuffer(r.cmdBuffers[i])
 		check(ret, "vk.EndCommandBuffer")
 	}
-	fenceCreateInfo := vk.FenceCreateInfo{
-		SType: vk.StructureTypeFenceCreateInfo,
+}
+
+// Recreate rebuilds the graphics pipeline for the new display size of the swapchain,
+// rotates the triangle for its pre-transform and records the command buffers again,
+// one per swapchain image.
+func (r *VulkanRenderInfo) Recreate(s *VulkanSwapchainInfo,
+	b *VulkanBufferInfo, gfx *VulkanGfxPipelineInfo) error {
+
+	gfx.Destroy()
+	var err error
+	*gfx, err = CreateGraphicsPipeline(r.device, s.DisplaySize, r.RenderPass)
+	if err != nil {
+		return err
 	}
-	semaphoreCreateInfo := vk.SemaphoreCreateInfo{
-		SType: vk.StructureTypeSemaphoreCreateInfo,
+	vk.FreeCommandBuffers(r.device, r.cmdPool, uint32(len(r.cmdBuffers)), r.cmdBuffers)
+	if err := r.CreateCommandBuffers(s.DefaultSwapchainLen()); err != nil {
+		return err
 	}
-	r.fences = make([]vk.Fence, 1)
-	ret := vk.CreateFence(v.Device, &fenceCreateInfo, nil, &r.fences[0])
-	check(ret, "vk.CreateFence")
-	r.semaphores = make([]vk.Semaphore, 1)
-	ret = vk.CreateSemaphore(v.Device, &semaphoreCreateInfo, nil, &r.semaphores[0])
-	check(ret, "vk.CreateSemaphore")
+	b.setTransform(s.Transform)
+	r.recordCommandBuffers(s.Framebuffers, s.DisplaySize, b, gfx)
+	// the device is idle after the swapchain recreation, no image is in use anymore
+	r.imageFences = make([]vk.Fence, len(r.cmdBuffers))
+	return nil
 }
 
-func VulkanDrawFrame(v VulkanDeviceInfo,
-	s VulkanSwapchainInfo, r VulkanRenderInfo) bool {
+// VulkanDrawFrame renders and presents a frame, it only blocks when r.FramesInFlight frames
+// are still being rendered. When the swapchain has become out of date or suboptimal
+// for the surface it sets s.OutOfDate, the caller is expected to call VulkanRecreate
+// before drawing the next frame.
+func VulkanDrawFrame(v *VulkanDeviceInfo,
+	s *VulkanSwapchainInfo, r *VulkanRenderInfo) bool {
 	var nextIdx uint32
+	frame := r.frame
+	frameFences := r.fences[frame : frame+1]
+
+	// Phase 1: vk.WaitForFences
+	//			wait until the GPU is done with the frame submitted
+	//			FramesInFlight frames ago
+
+	const timeoutNano = 10 * 1000 * 1000 * 1000 // 10 sec
+	err := vk.Error(vk.WaitForFences(v.Device, 1, frameFences, vk.True, timeoutNano))
+	if err != nil {
+		err = fmt.Errorf("vk.WaitForFences failed with %s", err)
+		log.Println("[WARN]", err)
+		return false
+	}
 
-	// Phase 1: vk.AcquireNextImage
+	// Phase 2: vk.AcquireNextImage
 	// 			get the framebuffer index we should draw in
 	//
 	//			N.B. non-infinite timeouts may be not yet implemented
 	//			by your Vulkan driver
 
-	err := vk.Error(vk.AcquireNextImage(v.Device, s.DefaultSwapchain(),
-		vk.MaxUint64, r.DefaultSemaphore(), vk.NullFence, &nextIdx))
+	ret := vk.AcquireNextImage(v.Device, s.DefaultSwapchain(),
+		vk.MaxUint64, r.semaphores[frame], vk.NullFence, &nextIdx)
+	switch ret {
+	case vk.ErrorOutOfDate:
+		// nothing has been acquired, the frame is skipped
+		s.OutOfDate = true
+		return false
+	case vk.Suboptimal:
+		// the image is still usable and the semaphore is signaled
+		s.OutOfDate = v.surfaceChanged(s)
+		ret = vk.Success
+	}
+	err = vk.Error(ret)
 	if err != nil {
 		err = fmt.Errorf("vk.AcquireNextImage failed with %s", err)
 		log.Println("[WARN]", err)
 		return false
 	}
 
-	// Phase 2: vk.QueueSubmit
-	//			vk.WaitForFences
+	// Phase 3: vk.WaitForFences
+	//			the image may be acquired out of order and still be rendered
+	//			by another frame in flight, its command buffer can't be reused until then
+
+	if imageFence := r.imageFences[nextIdx]; imageFence != vk.NullFence && imageFence != r.fences[frame] {
+		err = vk.Error(vk.WaitForFences(v.Device, 1, []vk.Fence{imageFence}, vk.True, timeoutNano))
+		if err != nil {
+			err = fmt.Errorf("vk.WaitForFences failed with %s", err)
+			log.Println("[WARN]", err)
+			return false
+		}
+	}
+	r.imageFences[nextIdx] = r.fences[frame]
+
+	// Phase 4: vk.QueueSubmit
 
-	vk.ResetFences(v.Device, 1, r.fences)
+	vk.ResetFences(v.Device, 1, frameFences)
 	submitInfo := []vk.SubmitInfo{{
-		SType:              vk.StructureTypeSubmitInfo,
-		WaitSemaphoreCount: 1,
-		PWaitSemaphores:    r.semaphores,
-		CommandBufferCount: 1,
-		PCommandBuffers:    r.cmdBuffers[nextIdx:],
+		SType:                vk.StructureTypeSubmitInfo,
+		WaitSemaphoreCount:   1,
+		PWaitSemaphores:      r.semaphores[frame : frame+1],
+		PWaitDstStageMask:    []vk.PipelineStageFlags{vk.PipelineStageFlags(vk.PipelineStageColorAttachmentOutputBit)},
+		CommandBufferCount:   1,
+		PCommandBuffers:      r.cmdBuffers[nextIdx:],
+		SignalSemaphoreCount: 1,
+		PSignalSemaphores:    r.renderSemaphores[frame : frame+1],
 	}}
-	err = vk.Error(vk.QueueSubmit(v.Queue, 1, submitInfo, r.DefaultFence()))
+	err = vk.Error(vk.QueueSubmit(v.Queue, 1, submitInfo, r.fences[frame]))
 	if err != nil {
+		r.restoreFrame(frame)
 		err = fmt.Errorf("vk.QueueSubmit failed with %s", err)
 		log.Println("[WARN]", err)
 		return false
 	}
-
-	const timeoutNano = 10 * 1000 * 1000 * 1000 // 10 sec
-	err = vk.Error(vk.WaitForFences(v.Device, 1, r.fences, vk.True, timeoutNano))
-	if err != nil {
-		err = fmt.Errorf("vk.WaitForFences failed with %s", err)
-		log.Println("[WARN]", err)
-		return false
+	r.frame = (frame + 1) % len(r.fences)
+	s.Current = nextIdx
+	s.layout = r.finalLayout
+	if s.captureNext {
+		// the image is only ours until it's presented
+		s.capture(s.Images[nextIdx], r.renderSemaphores[frame:frame+1])
 	}
 
-	// Phase 3: vk.QueuePresent
+	// Phase 5: vk.QueuePresent
+	//			wait for the rendering to finish on the GPU, not on the CPU
 
 	imageIndices := []uint32{nextIdx}
 	presentInfo := vk.PresentInfo{
-		SType:          vk.StructureTypePresentInfo,
-		SwapchainCount: 1,
-		PSwapchains:    s.Swapchains,
-		PImageIndices:  imageIndices,
-	}
-	err = vk.Error(vk.QueuePresent(v.Queue, &presentInfo))
+		SType:              vk.StructureTypePresentInfo,
+		WaitSemaphoreCount: 1,
+		PWaitSemaphores:    r.renderSemaphores[frame : frame+1],
+		SwapchainCount:     1,
+		PSwapchains:        s.Swapchains,
+		PImageIndices:      imageIndices,
+	}
+	ret = vk.QueuePresent(v.PresentQueue, &presentInfo)
+	switch ret {
+	case vk.ErrorOutOfDate:
+		s.OutOfDate = true
+		return true
+	case vk.Suboptimal:
+		s.OutOfDate = v.surfaceChanged(s)
+		return true
+	}
+	err = vk.Error(ret)
 	if err != nil {
 		err = fmt.Errorf("vk.QueuePresent failed with %s", err)
 		log.Println("[WARN]", err)
@@ -194,6 +397,22 @@ func VulkanDrawFrame(v VulkanDeviceInfo,
 	return true
 }
 
+// surfaceChanged tells whether the surface extent or its transform differs from the swapchain,
+// e.g. the window has been resized or the Android device has been rotated. A swapchain
+// may stay suboptimal for other reasons, recreating it every frame wouldn't help then.
+func (v *VulkanDeviceInfo) surfaceChanged(s *VulkanSwapchainInfo) bool {
+	var surfaceCapabilities vk.SurfaceCapabilities
+	err := vk.Error(vk.GetPhysicalDeviceSurfaceCapabilities(v.gpu, v.Surface, &surfaceCapabilities))
+	if err != nil {
+		return true
+	}
+	surfaceCapabilities.Deref()
+	surfaceCapabilities.CurrentExtent.Deref()
+	return surfaceCapabilities.CurrentExtent.Width != s.DisplaySize.Width ||
+		surfaceCapabilities.CurrentExtent.Height != s.DisplaySize.Height ||
+		surfaceCapabilities.CurrentTransform != s.Transform
+}
+
 func (r *VulkanRenderInfo) CreateCommandBuffers(n uint32) error {
 	r.cmdBuffers = make([]vk.CommandBuffer, n)
 	cmdBufferAllocateInfo := vk.CommandBufferAllocateInfo{
@@ -210,7 +429,13 @@ func (r *VulkanRenderInfo) CreateCommandBuffers(n uint32) error {
 	return nil
 }
 
-func CreateRenderer(device vk.Device, displayFormat vk.Format) (VulkanRenderInfo, error) {
+// CreateRenderer creates the render pass and a command pool for the queue family,
+// which must be the graphics queue family the command buffers are submitted to.
+// The render pass leaves the images in finalLayout, vk.ImageLayoutPresentSrc for swapchain
+// images and vk.ImageLayoutColorAttachmentOptimal for offscreen ones.
+func CreateRenderer(device vk.Device, displayFormat vk.Format, finalLayout vk.ImageLayout,
+	queueFamilyIndex uint32) (VulkanRenderInfo, error) {
+
 	attachmentDescriptions := []vk.AttachmentDescription{{
 		Format:         displayFormat,
 		Samples:        vk.SampleCount1Bit,
@@ -218,8 +443,8 @@ func CreateRenderer(device vk.Device, displayFormat vk.Format) (VulkanRenderInfo
 		StoreOp:        vk.AttachmentStoreOpStore,
 		StencilLoadOp:  vk.AttachmentLoadOpDontCare,
 		StencilStoreOp: vk.AttachmentStoreOpDontCare,
-		InitialLayout:  vk.ImageLayoutColorAttachmentOptimal,
-		FinalLayout:    vk.ImageLayoutColorAttachmentOptimal,
+		InitialLayout:  vk.ImageLayoutUndefined, // cleared anyway, offscreen images start undefined
+		FinalLayout:    finalLayout,
 	}}
 	colorAttachments := []vk.AttachmentReference{{
 		Attachment: 0,
@@ -240,7 +465,7 @@ func CreateRenderer(device vk.Device, displayFormat vk.Format) (VulkanRenderInfo
 	cmdPoolCreateInfo := vk.CommandPoolCreateInfo{
 		SType:            vk.StructureTypeCommandPoolCreateInfo,
 		Flags:            vk.CommandPoolCreateFlags(vk.CommandPoolCreateResetCommandBufferBit),
-		QueueFamilyIndex: 0,
+		QueueFamilyIndex: queueFamilyIndex,
 	}
 	var r VulkanRenderInfo
 	err := vk.Error(vk.CreateRenderPass(device, &renderPassCreateInfo, nil, &r.RenderPass))
@@ -254,33 +479,51 @@ func CreateRenderer(device vk.Device, displayFormat vk.Format) (VulkanRenderInfo
 		return r, err
 	}
 	r.device = device
+	r.finalLayout = finalLayout
 	return r, nil
 }
 
-func NewVulkanDevice(appInfo *vk.ApplicationInfo, window uintptr, instanceExtensions []string, createSurfaceFunc func(interface{}) uintptr) (VulkanDeviceInfo, error) {
+// NewVulkanDevice creates the instance, the surface and the logical device as configured
+// by the options. Without opts.CreateSurface the device is headless and renders offscreen only,
+// see CreateOffscreen.
+func NewVulkanDevice(appInfo *vk.ApplicationInfo, opts DeviceOptions) (VulkanDeviceInfo, error) {
 	// Phase 1: vk.CreateInstance with vk.InstanceCreateInfo
 
-	existingExtensions := getInstanceExtensions()
+	existingExtensions := getInstanceExtensions("")
 	log.Println("[INFO] Instance extensions:", existingExtensions)
 
-	// instanceExtensions := vk.GetRequiredInstanceExtensions()
-	if enableDebug {
-		instanceExtensions = append(instanceExtensions,
-			"VK_EXT_debug_report\x00")
-	}
+	instanceExtensions := cstr.Strings(opts.InstanceExtensions)
+	instanceLayers := cstr.Strings(opts.Layers)
+	validation := opts.Validation || EnableValidation
 
 	// ANDROID:
-	// these layers must be included in APK,
+	// the validation layers must be included in APK,
 	// see Android.mk and ValidationLayers.mk
-	instanceLayers := []string{
-		// "VK_LAYER_GOOGLE_threading\x00",
-		// "VK_LAYER_LUNARG_parameter_validation\x00",
-		// "VK_LAYER_LUNARG_object_tracker\x00",
-		// "VK_LAYER_LUNARG_core_validation\x00",
-		// "VK_LAYER_LUNARG_api_dump\x00",
-		// "VK_LAYER_LUNARG_image\x00",
-		// "VK_LAYER_LUNARG_swapchain\x00",
-		// "VK_LAYER_GOOGLE_unique_objects\x00",
+	var debugUtils, debugReport bool
+	if validation {
+		validationLayers := selectValidationLayers(getInstanceLayers())
+		if len(validationLayers) == 0 {
+			log.Println("[WARN] validation requested but no validation layers are available")
+		}
+		// the debug extensions may be provided by the layers only
+		debugExtensions := existingExtensions
+		for _, layer := range validationLayers {
+			debugExtensions = append(debugExtensions, getInstanceExtensions(layer)...)
+		}
+		switch {
+		case hasExtension(debugExtensions, "VK_EXT_debug_utils"):
+			debugUtils = true
+			instanceExtensions = append(instanceExtensions, "VK_EXT_debug_utils\x00")
+		case hasExtension(debugExtensions, "VK_EXT_debug_report"):
+			// Nvidia Shield K1 fw 1.3.0 lacks this extension,
+			// on fw 1.2.0 it works fine.
+			debugReport = true
+			instanceExtensions = append(instanceExtensions, "VK_EXT_debug_report\x00")
+		default:
+			log.Println("[WARN] validation requested but no debug extension is available")
+		}
+		log.Println("[INFO] Validation layers:", validationLayers)
+		instanceLayers = append(instanceLayers, validationLayers...)
 	}
 
 	instanceCreateInfo := vk.InstanceCreateInfo{
@@ -291,57 +534,91 @@ func NewVulkanDevice(appInfo *vk.ApplicationInfo, window uintptr, instanceExtens
 		EnabledLayerCount:       uint32(len(instanceLayers)),
 		PpEnabledLayerNames:     instanceLayers,
 	}
-	var v VulkanDeviceInfo
-	err := vk.Error(vk.CreateInstance(&instanceCreateInfo, nil, &v.Instance))
-	if err != nil {
+	v := VulkanDeviceInfo{
+		allocator: opts.Allocator,
+	}
+	ret := vk.CreateInstance(&instanceCreateInfo, v.allocator, &v.Instance)
+	err := vk.Error(ret)
+	if ret == vk.ErrorIncompatibleDriver {
+		err = fmt.Errorf("vk.CreateInstance failed with %s: %w", err, ErrNoDevice)
+		return v, err
+	} else if err != nil {
 		err = fmt.Errorf("vk.CreateInstance failed with %s", err)
 		return v, err
 	} else {
 		vk.InitInstance(v.Instance)
 	}
 
-	// Phase 2: vk.CreateAndroidSurface with vk.AndroidSurfaceCreateInfo
+	// Phase 2: vk.CreateWindowSurface or the window system's equivalent
+	//			skipped in headless mode, when there is no opts.CreateSurface
 
-	v.Surface = vk.SurfaceFromPointer(createSurfaceFunc(v.Instance))
-	// err = vk.Error(vk.CreateWindowSurface(v.Instance, window, nil, &v.Surface))
-	if err != nil {
-		vk.DestroyInstance(v.Instance, nil)
-		err = fmt.Errorf("vkCreateWindowSurface failed with %s", err)
-		return v, err
+	if opts.CreateSurface != nil {
+		v.Surface, err = opts.CreateSurface(v.Instance)
+		if err != nil {
+			vk.DestroyInstance(v.Instance, v.allocator)
+			err = fmt.Errorf("vkCreateWindowSurface failed with %s", err)
+			return v, err
+		}
 	}
 	if v.gpuDevices, err = getPhysicalDevices(v.Instance); err != nil {
 		v.gpuDevices = nil
-		vk.DestroySurface(v.Instance, v.Surface, nil)
-		vk.DestroyInstance(v.Instance, nil)
+		v.destroySurface()
+		vk.DestroyInstance(v.Instance, v.allocator)
+		return v, err
+	}
+
+	deviceExtensions := cstr.Strings(opts.DeviceExtensions)
+	if !v.Headless() {
+		deviceExtensions = append(deviceExtensions, "VK_KHR_swapchain\x00")
+	}
+	override := opts.Device
+	if len(override) == 0 {
+		override = DeviceOverride
+	}
+	if err = v.selectPhysicalDevice(override, deviceExtensions, opts.Features, opts.PickDevice); err != nil {
+		v.gpuDevices = nil
+		v.destroySurface()
+		vk.DestroyInstance(v.Instance, v.allocator)
 		return v, err
 	}
+	log.Printf("[INFO] selected physical device #%d %s", v.PhysicalDeviceIndex, v.PhysicalDeviceName)
+	log.Println("[INFO] graphics queue family:", v.GraphicsQueueFamily,
+		"present queue family:", v.PresentQueueFamily)
 
-	existingExtensions = getDeviceExtensions(v.gpuDevices[0])
+	existingExtensions = getDeviceExtensions(v.gpu)
 	log.Println("[INFO] Device extensions:", existingExtensions)
 
 	// Phase 3: vk.CreateDevice with vk.DeviceCreateInfo (a logical device)
 
-	// ANDROID:
-	// these layers must be included in APK,
-	// see Android.mk and ValidationLayers.mk
-	deviceLayers := []string{
-		// "VK_LAYER_GOOGLE_threading\x00",
-		// "VK_LAYER_LUNARG_parameter_validation\x00",
-		// "VK_LAYER_LUNARG_object_tracker\x00",
-		// "VK_LAYER_LUNARG_core_validation\x00",
-		// "VK_LAYER_LUNARG_api_dump\x00",
-		// "VK_LAYER_LUNARG_image\x00",
-		// "VK_LAYER_LUNARG_swapchain\x00",
-		// "VK_LAYER_GOOGLE_unique_objects\x00",
+	// device layers are deprecated, but older implementations
+	// expect the same layers as the instance
+	var deviceLayers []string
+	if len(instanceLayers) > 0 {
+		available := getDeviceLayers(v.gpu)
+		for _, layer := range instanceLayers {
+			if hasExtension(available, strings.TrimSuffix(layer, "\x00")) {
+				deviceLayers = append(deviceLayers, layer)
+			}
+		}
 	}
 
 	queueCreateInfos := []vk.DeviceQueueCreateInfo{{
 		SType:            vk.StructureTypeDeviceQueueCreateInfo,
+		QueueFamilyIndex: v.GraphicsQueueFamily,
 		QueueCount:       1,
 		PQueuePriorities: []float32{1.0},
 	}}
-	deviceExtensions := []string{
-		"VK_KHR_swapchain\x00",
+	if v.PresentQueueFamily != v.GraphicsQueueFamily {
+		queueCreateInfos = append(queueCreateInfos, vk.DeviceQueueCreateInfo{
+			SType:            vk.StructureTypeDeviceQueueCreateInfo,
+			QueueFamilyIndex: v.PresentQueueFamily,
+			QueueCount:       1,
+			PQueuePriorities: []float32{1.0},
+		})
+	}
+	var enabledFeatures []vk.PhysicalDeviceFeatures
+	if opts.Features != nil {
+		enabledFeatures = []vk.PhysicalDeviceFeatures{*opts.Features}
 	}
 	deviceCreateInfo := vk.DeviceCreateInfo{
 		SType:                   vk.StructureTypeDeviceCreateInfo,
@@ -351,48 +628,43 @@ func NewVulkanDevice(appInfo *vk.ApplicationInfo, window uintptr, instanceExtens
 		PpEnabledExtensionNames: deviceExtensions,
 		EnabledLayerCount:       uint32(len(deviceLayers)),
 		PpEnabledLayerNames:     deviceLayers,
+		PEnabledFeatures:        enabledFeatures,
 	}
-	var device vk.Device // we choose the first GPU available for this device
-	err = vk.Error(vk.CreateDevice(v.gpuDevices[0], &deviceCreateInfo, nil, &device))
+	var device vk.Device
+	err = vk.Error(vk.CreateDevice(v.gpu, &deviceCreateInfo, v.allocator, &device))
 	if err != nil {
 		v.gpuDevices = nil
-		vk.DestroySurface(v.Instance, v.Surface, nil)
-		vk.DestroyInstance(v.Instance, nil)
+		v.destroySurface()
+		vk.DestroyInstance(v.Instance, v.allocator)
 		err = fmt.Errorf("vk.CreateDevice failed with %s", err)
 		return v, err
 	} else {
 		v.Device = device
 		var queue vk.Queue
-		vk.GetDeviceQueue(device, 0, 0, &queue)
+		vk.GetDeviceQueue(device, v.GraphicsQueueFamily, 0, &queue)
 		v.Queue = queue
+		vk.GetDeviceQueue(device, v.PresentQueueFamily, 0, &queue)
+		v.PresentQueue = queue
 	}
 
-	if enableDebug {
-		// Phase 4: vk.CreateDebugReportCallback
+	if debugUtils || debugReport {
+		// Phase 4: vkCreateDebugUtilsMessengerEXT or vk.CreateDebugReportCallback
 
-		dbgCreateInfo := vk.DebugReportCallbackCreateInfo{
-			SType:       vk.StructureTypeDebugReportCallbackCreateInfo,
-			Flags:       vk.DebugReportFlags(vk.DebugReportErrorBit | vk.DebugReportWarningBit),
-			PfnCallback: dbgCallbackFunc,
-		}
-		var dbg vk.DebugReportCallback
-		err = vk.Error(vk.CreateDebugReportCallback(v.Instance, &dbgCreateInfo, nil, &dbg))
-		if err != nil {
-			err = fmt.Errorf("vk.CreateDebugReportCallback failed with %s", err)
+		if err := v.createDebugMessenger(debugUtils, opts.Logger, opts.Severity); err != nil {
 			log.Println("[WARN]", err)
-			return v, nil
 		}
-		v.dbg = dbg
 	}
 	return v, nil
 }
 
-func getInstanceExtensions() (extNames []string) {
+// getInstanceExtensions lists the extensions of the implementation or of a layer,
+// the layer name must be null-terminated.
+func getInstanceExtensions(layerName string) (extNames []string) {
 	var instanceExtLen uint32
-	ret := vk.EnumerateInstanceExtensionProperties("", &instanceExtLen, nil)
+	ret := vk.EnumerateInstanceExtensionProperties(layerName, &instanceExtLen, nil)
 	check(ret, "vk.EnumerateInstanceExtensionProperties")
 	instanceExt := make([]vk.ExtensionProperties, instanceExtLen)
-	ret = vk.EnumerateInstanceExtensionProperties("", &instanceExtLen, instanceExt)
+	ret = vk.EnumerateInstanceExtensionProperties(layerName, &instanceExtLen, instanceExt)
 	check(ret, "vk.EnumerateInstanceExtensionProperties")
 	for _, ext := range instanceExt {
 		ext.Deref()
@@ -417,19 +689,156 @@ func getDeviceExtensions(gpu vk.PhysicalDevice) (extNames []string) {
 	return extNames
 }
 
-func dbgCallbackFunc(flags vk.DebugReportFlags, objectType vk.DebugReportObjectType,
-	object uint64, location uint, messageCode int32, pLayerPrefix string,
-	pMessage string, pUserData unsafe.Pointer) vk.Bool32 {
+// selectPhysicalDevice picks the physical device to render with, only devices supporting
+// the device extensions and the features and presenting to the surface (unless headless)
+// are considered. The override is either a device index or a part of the device name,
+// then pick chooses among the remaining devices, pickDeviceByType if it is nil.
+func (v *VulkanDeviceInfo) selectPhysicalDevice(override string, extensions []string,
+	features *vk.PhysicalDeviceFeatures, pick func([]DeviceCandidate) int) error {
+
+	var candidates []DeviceCandidate
+	var rejected []string
+	for i, gpu := range v.gpuDevices {
+		var properties vk.PhysicalDeviceProperties
+		vk.GetPhysicalDeviceProperties(gpu, &properties)
+		properties.Deref()
+		name := vk.ToString(properties.DeviceName[:])
+
+		if !matchesOverride(override, i, name) {
+			continue
+		}
+		available := getDeviceExtensions(gpu)
+		var missing []string
+		for _, ext := range extensions {
+			if ext = strings.TrimSuffix(ext, "\x00"); !hasExtension(available, ext) {
+				missing = append(missing, ext)
+			}
+		}
+		if len(missing) > 0 {
+			rejected = append(rejected, name+": no "+strings.Join(missing, ", "))
+			continue
+		}
+		if features != nil {
+			if missing := missingFeatures(gpu, features); len(missing) > 0 {
+				rejected = append(rejected, name+": no "+strings.Join(missing, ", "))
+				continue
+			}
+		}
+		graphics, present, err := selectQueueFamilies(gpu, v.Surface)
+		if err != nil {
+			rejected = append(rejected, name+": "+err.Error())
+			continue
+		}
+		candidates = append(candidates, DeviceCandidate{
+			Index:  i,
+			Name:   name,
+			Type:   properties.DeviceType,
+			Device: gpu,
+
+			graphicsQueueFamily: graphics,
+			presentQueueFamily:  present,
+		})
+	}
+	selected := -1
+	if len(candidates) > 0 {
+		if pick == nil {
+			pick = pickDeviceByType
+		}
+		selected = pick(candidates)
+	}
+	if selected < 0 || selected >= len(candidates) {
+		err := fmt.Errorf("selectPhysicalDevice: %w found", ErrNoDevice)
+		if len(override) > 0 {
+			err = fmt.Errorf("selectPhysicalDevice: %w matches %q", ErrNoDevice, override)
+		}
+		if len(rejected) > 0 {
+			err = fmt.Errorf("%w (%s)", err, strings.Join(rejected, "; "))
+		}
+		return err
+	}
+	c := candidates[selected]
+	v.PhysicalDeviceIndex = c.Index
+	v.PhysicalDeviceName = c.Name
+	v.GraphicsQueueFamily, v.PresentQueueFamily = c.graphicsQueueFamily, c.presentQueueFamily
+	v.gpu = c.Device
+	return nil
+}
 
-	switch {
-	case flags&vk.DebugReportFlags(vk.DebugReportErrorBit) != 0:
-		log.Printf("[ERROR %d] %s on layer %s", messageCode, pMessage, pLayerPrefix)
-	case flags&vk.DebugReportFlags(vk.DebugReportWarningBit) != 0:
-		log.Printf("[WARN %d] %s on layer %s", messageCode, pMessage, pLayerPrefix)
-	default:
-		log.Printf("[WARN] unknown debug message %d (layer %s)", messageCode, pLayerPrefix)
+// matchesOverride tells whether the physical device is selected by the override,
+// a number selects the device by its index only, anything else is a part of the name.
+func matchesOverride(override string, index int, name string) bool {
+	if len(override) == 0 {
+		return true
+	}
+	if overrideIdx, err := strconv.Atoi(override); err == nil {
+		return index == overrideIdx
 	}
-	return vk.Bool32(vk.False)
+	return strings.Contains(strings.ToLower(name), strings.ToLower(override))
+}
+
+// Headless tells whether the device has been created without a surface,
+// it can only render into a VulkanOffscreenInfo then.
+func (v *VulkanDeviceInfo) Headless() bool {
+	return v.Surface == vk.NullSurface
+}
+
+func (v *VulkanDeviceInfo) destroySurface() {
+	if v.Surface != vk.NullSurface {
+		vk.DestroySurface(v.Instance, v.Surface, v.allocator)
+	}
+}
+
+func hasExtension(extNames []string, name string) bool {
+	for _, ext := range extNames {
+		if ext == name {
+			return true
+		}
+	}
+	return false
+}
+
+// selectQueueFamilies finds a graphics queue family and a queue family that can present
+// to the surface, preferring a single family that supports both.
+func selectQueueFamilies(gpu vk.PhysicalDevice, surface vk.Surface) (graphics, present uint32, err error) {
+	var familyCount uint32
+	vk.GetPhysicalDeviceQueueFamilyProperties(gpu, &familyCount, nil)
+	families := make([]vk.QueueFamilyProperties, familyCount)
+	vk.GetPhysicalDeviceQueueFamilyProperties(gpu, &familyCount, families)
+
+	graphicsFound, presentFound := false, false
+	for i := uint32(0); i < familyCount; i++ {
+		families[i].Deref()
+		isGraphics := families[i].QueueCount > 0 &&
+			families[i].QueueFlags&vk.QueueFlags(vk.QueueGraphicsBit) != 0
+		if surface == vk.NullSurface {
+			// headless, nothing to present to
+			if isGraphics {
+				return i, i, nil
+			}
+			continue
+		}
+		var supported vk.Bool32
+		err = vk.Error(vk.GetPhysicalDeviceSurfaceSupport(gpu, i, surface, &supported))
+		if err != nil {
+			err = fmt.Errorf("vk.GetPhysicalDeviceSurfaceSupport failed with %s", err)
+			return 0, 0, err
+		}
+		canPresent := supported.B()
+		if isGraphics && canPresent {
+			return i, i, nil
+		}
+		if isGraphics && !graphicsFound {
+			graphics, graphicsFound = i, true
+		}
+		if canPresent && !presentFound {
+			present, presentFound = i, true
+		}
+	}
+	if !graphicsFound || !presentFound {
+		err = fmt.Errorf("selectQueueFamilies: no graphics and present capable queue families found")
+		return 0, 0, err
+	}
+	return graphics, present, nil
 }
 
 func getPhysicalDevices(instance vk.Instance) ([]vk.PhysicalDevice, error) {
@@ -440,7 +849,7 @@ func getPhysicalDevices(instance vk.Instance) ([]vk.PhysicalDevice, error) {
 		return nil, err
 	}
 	if gpuCount == 0 {
-		err = fmt.Errorf("getPhysicalDevice: no GPUs found on the system")
+		err = fmt.Errorf("getPhysicalDevice: %w, no GPUs found on the system", ErrNoDevice)
 		return nil, err
 	}
 	gpuList := make([]vk.PhysicalDevice, gpuCount)
@@ -453,17 +862,26 @@ func getPhysicalDevices(instance vk.Instance) ([]vk.PhysicalDevice, error) {
 }
 
 func (v *VulkanDeviceInfo) CreateSwapchain() (VulkanSwapchainInfo, error) {
-	gpu := v.gpuDevices[0]
+	s, _, err := v.createSwapchain(vk.NullSwapchain)
+	return s, err
+}
+
+// createSwapchain creates a swapchain for the current surface capabilities, the old swapchain
+// (if any) is handed over to the driver so it can reuse its resources and it must be destroyed
+// by the caller afterwards. It reports whether the old swapchain has been retired, which happens
+// once vk.CreateSwapchain is called even if it fails.
+func (v *VulkanDeviceInfo) createSwapchain(oldSwapchain vk.Swapchain) (s VulkanSwapchainInfo,
+	retired bool, err error) {
+	gpu := v.gpu
 
 	// Phase 1: vk.GetPhysicalDeviceSurfaceCapabilities
 	//			vk.GetPhysicalDeviceSurfaceFormats
 
-	var s VulkanSwapchainInfo
 	var surfaceCapabilities vk.SurfaceCapabilities
-	err := vk.Error(vk.GetPhysicalDeviceSurfaceCapabilities(gpu, v.Surface, &surfaceCapabilities))
+	err = vk.Error(vk.GetPhysicalDeviceSurfaceCapabilities(gpu, v.Surface, &surfaceCapabilities))
 	if err != nil {
 		err = fmt.Errorf("vk.GetPhysicalDeviceSurfaceCapabilities failed with %s", err)
-		return s, err
+		return s, false, err
 	}
 	var formatCount uint32
 	vk.GetPhysicalDeviceSurfaceFormats(gpu, v.Surface, &formatCount, nil)
@@ -483,7 +901,7 @@ func (v *VulkanDeviceInfo) CreateSwapchain() (VulkanSwapchainInfo, error) {
 	}
 	if chosenFormat < 0 {
 		err := fmt.Errorf("vk.GetPhysicalDeviceSurfaceFormats not found suitable format")
-		return s, err
+		return s, false, err
 	}
 
 	// Phase 2: vk.CreateSwapchain
@@ -493,7 +911,21 @@ func (v *VulkanDeviceInfo) CreateSwapchain() (VulkanSwapchainInfo, error) {
 	s.DisplaySize = surfaceCapabilities.CurrentExtent
 	s.DisplaySize.Deref()
 	s.DisplayFormat = formats[chosenFormat].Format
-	queueFamily := []uint32{0}
+	s.Transform = surfaceCapabilities.CurrentTransform
+	// the images are rendered on the graphics queue and presented on the present queue,
+	// share them concurrently if those are different families to avoid ownership transfers.
+	queueFamilies := []uint32{v.GraphicsQueueFamily}
+	sharingMode := vk.SharingModeExclusive
+	if v.PresentQueueFamily != v.GraphicsQueueFamily {
+		queueFamilies = append(queueFamilies, v.PresentQueueFamily)
+		sharingMode = vk.SharingModeConcurrent
+	}
+	// the images can be read back only if the surface allows to copy from them
+	imageUsage := vk.ImageUsageFlags(vk.ImageUsageColorAttachmentBit)
+	if surfaceCapabilities.SupportedUsageFlags&vk.ImageUsageFlags(vk.ImageUsageTransferSrcBit) != 0 {
+		imageUsage |= vk.ImageUsageFlags(vk.ImageUsageTransferSrcBit)
+		s.readback = v.newReadback()
+	}
 	swapchainCreateInfo := vk.SwapchainCreateInfo{
 		SType:           vk.StructureTypeSwapchainCreateInfo,
 		Surface:         v.Surface,
@@ -501,34 +933,65 @@ func (v *VulkanDeviceInfo) CreateSwapchain() (VulkanSwapchainInfo, error) {
 		ImageFormat:     formats[chosenFormat].Format,
 		ImageColorSpace: formats[chosenFormat].ColorSpace,
 		ImageExtent:     surfaceCapabilities.CurrentExtent,
-		ImageUsage:      vk.ImageUsageFlags(vk.ImageUsageColorAttachmentBit),
-		PreTransform:    vk.SurfaceTransformIdentityBit,
+		ImageUsage:      imageUsage,
+		PreTransform:    s.Transform,
 
 		ImageArrayLayers:      1,
-		ImageSharingMode:      vk.SharingModeExclusive,
-		QueueFamilyIndexCount: 1,
-		PQueueFamilyIndices:   queueFamily,
+		ImageSharingMode:      sharingMode,
+		QueueFamilyIndexCount: uint32(len(queueFamilies)),
+		PQueueFamilyIndices:   queueFamilies,
 		PresentMode:           vk.PresentModeFifo,
-		OldSwapchain:          vk.NullSwapchain,
+		OldSwapchain:          oldSwapchain,
 		Clipped:               vk.False,
 	}
+	retired = oldSwapchain != vk.NullSwapchain
 	s.Swapchains = make([]vk.Swapchain, 1)
 	err = vk.Error(vk.CreateSwapchain(v.Device, &swapchainCreateInfo, nil, &(s.Swapchains[0])))
 	if err != nil {
 		err = fmt.Errorf("vk.CreateSwapchain failed with %s", err)
-		return s, err
+		return s, retired, err
 	}
 	s.SwapchainLen = make([]uint32, 1)
 	err = vk.Error(vk.GetSwapchainImages(v.Device, s.DefaultSwapchain(), &(s.SwapchainLen[0]), nil))
 	if err != nil {
+		vk.DestroySwapchain(v.Device, s.DefaultSwapchain(), nil)
 		err = fmt.Errorf("vk.GetSwapchainImages failed with %s", err)
-		return s, err
+		return s, retired, err
 	}
 	for i := range formats {
 		formats[i].Free()
 	}
 	s.Device = v.Device
-	return s, nil
+	return s, retired, nil
+}
+
+// Recreate replaces the swapchain, its image views and framebuffers with new ones
+// matching the current size of the surface, it waits for the device to be idle first.
+// On failure the old swapchain is kept, so Recreate can be retried or s destroyed.
+func (s *VulkanSwapchainInfo) Recreate(v *VulkanDeviceInfo, renderPass vk.RenderPass) error {
+	err := vk.Error(vk.DeviceWaitIdle(v.Device))
+	if err != nil {
+		err = fmt.Errorf("vk.DeviceWaitIdle failed with %s", err)
+		return err
+	}
+	oldSwapchain := s.DefaultSwapchain()
+	handover := oldSwapchain
+	if s.retired {
+		handover = vk.NullSwapchain
+	}
+	newSwapchain, retired, err := v.createSwapchain(handover)
+	if err != nil {
+		s.retired = s.retired || retired
+		return err
+	}
+	s.destroyFramebuffers()
+	vk.DestroySwapchain(v.Device, oldSwapchain, nil)
+	// a capture requested for a frame that has been skipped is still pending
+	captureNext := s.captureNext
+	*s = newSwapchain
+	s.captureNext = captureNext && s.enabled
+	log.Println("[INFO] swapchain recreated:", s.DisplaySize.Width, "x", s.DisplaySize.Height)
+	return s.CreateFramebuffers(renderPass, vk.NullImageView)
 }
 
 func (s *VulkanSwapchainInfo) CreateFramebuffers(renderPass vk.RenderPass, depthView vk.ImageView) error {
@@ -571,7 +1034,7 @@ func (s *VulkanSwapchainInfo) CreateFramebuffers(renderPass vk.RenderPass, depth
 			return err // bail out
 		}
 	}
-	swapchainImages = nil
+	s.Images = swapchainImages
 
 	// Phase 3: vk.CreateFramebuffer
 	//			create a framebuffer from each swapchain image
@@ -603,17 +1066,13 @@ func (s *VulkanSwapchainInfo) CreateFramebuffers(renderPass vk.RenderPass, depth
 }
 
 func (v VulkanDeviceInfo) CreateBuffers() (VulkanBufferInfo, error) {
-	gpu := v.gpuDevices[0]
+	gpu := v.gpu
 
 	// Phase 1: vk.CreateBuffer
 	//			create the triangle vertex buffer
 
-	vertexData := linmath.ArrayFloat32([]float32{
-		-1, -1, 0,
-		1, -1, 0,
-		0, 1, 0,
-	})
-	queueFamilyIdx := []uint32{0}
+	vertexData := linmath.ArrayFloat32(triangle)
+	queueFamilyIdx := []uint32{v.GraphicsQueueFamily}
 	bufferCreateInfo := vk.BufferCreateInfo{
 		SType:                 vk.StructureTypeBufferCreateInfo,
 		Size:                  vk.DeviceSize(vertexData.Sizeof()),
@@ -652,36 +1111,72 @@ func (v VulkanDeviceInfo) CreateBuffers() (VulkanBufferInfo, error) {
 	//			vk.UnmapMemory
 	// 			allocate and map memory for that buffer
 
-	var deviceMemory vk.DeviceMemory
-	err = vk.Error(vk.AllocateMemory(v.Device, &allocInfo, nil, &deviceMemory))
+	err = vk.Error(vk.AllocateMemory(v.Device, &allocInfo, nil, &buffer.memory))
 	if err != nil {
 		err = fmt.Errorf("vk.AllocateMemory failed with %s", err)
 		return buffer, err
 	}
-	var data unsafe.Pointer
-	vk.MapMemory(v.Device, deviceMemory, 0, vk.DeviceSize(vertexData.Sizeof()), 0, &data)
-	n := vk.Memcopy(data, vertexData.Data())
-	if n != vertexData.Sizeof() {
-		log.Println("[WARN] failed to copy vertex buffer data")
-	}
-	vk.UnmapMemory(v.Device, deviceMemory)
+	buffer.device = v.Device
+	buffer.writeVertices(vertexData)
 
 	// Phase 4: vk.BindBufferMemory
 	//			copy vertex data and bind buffer
 
-	err = vk.Error(vk.BindBufferMemory(v.Device, buffer.DefaultVertexBuffer(), deviceMemory, 0))
+	err = vk.Error(vk.BindBufferMemory(v.Device, buffer.DefaultVertexBuffer(), buffer.memory, 0))
 	if err != nil {
 		err = fmt.Errorf("vk.BindBufferMemory failed with %s", err)
 		return buffer, err
 	}
-	buffer.device = v.Device
 	return buffer, err
 }
 
+// triangle holds the vertices of the triangle in clip space.
+var triangle = []float32{
+	-1, -1, 0,
+	1, -1, 0,
+	0, 1, 0,
+}
+
+// setTransform rotates the triangle so it is upright once the presentation engine has applied
+// the pre-transform of the swapchain, the buffer must not be in use by the GPU.
+func (buf *VulkanBufferInfo) setTransform(transform vk.SurfaceTransformFlagBits) {
+	buf.writeVertices(linmath.ArrayFloat32(pretransform(triangle, transform)))
+}
+
+// pretransform rotates the xyz vertices in clip space by the rotation of the transform,
+// the presentation engine rotates them back when it applies the transform.
+func pretransform(vertices []float32, transform vk.SurfaceTransformFlagBits) []float32 {
+	rotated := make([]float32, len(vertices))
+	for i := 0; i < len(vertices); i += 3 {
+		x, y := vertices[i], vertices[i+1]
+		switch transform {
+		case vk.SurfaceTransformRotate90Bit:
+			x, y = -y, x
+		case vk.SurfaceTransformRotate180Bit:
+			x, y = -x, -y
+		case vk.SurfaceTransformRotate270Bit:
+			x, y = y, -x
+		}
+		rotated[i], rotated[i+1], rotated[i+2] = x, y, vertices[i+2]
+	}
+	return rotated
+}
+
+func (buf *VulkanBufferInfo) writeVertices(vertexData linmath.ArrayFloat32) {
+	var data unsafe.Pointer
+	vk.MapMemory(buf.device, buf.memory, 0, vk.DeviceSize(vertexData.Sizeof()), 0, &data)
+	n := vk.Memcopy(data, vertexData.Data())
+	if n != vertexData.Sizeof() {
+		log.Println("[WARN] failed to copy vertex buffer data")
+	}
+	vk.UnmapMemory(buf.device, buf.memory)
+}
+
 func (buf *VulkanBufferInfo) Destroy() {
 	for i := range buf.vertexBuffers {
 		vk.DestroyBuffer(buf.device, buf.vertexBuffers[i], nil)
 	}
+	vk.FreeMemory(buf.device, buf.memory, nil)
 }
 
 func LoadShader(device vk.Device, name string) (vk.ShaderModule, error) {
@@ -891,32 +1386,57 @@ func (gfx *VulkanGfxPipelineInfo) Destroy() {
 }
 
 func (s *VulkanSwapchainInfo) Destroy() {
-	for i := uint32(0); i < s.DefaultSwapchainLen(); i++ {
+	s.destroyFramebuffers()
+	for i := range s.Swapchains {
+		vk.DestroySwapchain(s.Device, s.Swapchains[i], nil)
+	}
+}
+
+func (s *VulkanSwapchainInfo) destroyFramebuffers() {
+	for i := range s.Framebuffers {
 		vk.DestroyFramebuffer(s.Device, s.Framebuffers[i], nil)
+	}
+	for i := range s.DisplayViews {
 		vk.DestroyImageView(s.Device, s.DisplayViews[i], nil)
 	}
+	s.Images = nil
 	s.Framebuffers = nil
 	s.DisplayViews = nil
-	for i := range s.Swapchains {
-		vk.DestroySwapchain(s.Device, s.Swapchains[i], nil)
-	}
 }
 
 func DestroyInOrder(v *VulkanDeviceInfo, s *VulkanSwapchainInfo,
 	r *VulkanRenderInfo, b *VulkanBufferInfo, gfx *VulkanGfxPipelineInfo) {
 
+	r.destroy(v)
+	s.Destroy()
+	v.destroy(b, gfx)
+}
+
+func (r *VulkanRenderInfo) destroy(v *VulkanDeviceInfo) {
+	// frames may still be in flight
+	vk.DeviceWaitIdle(v.Device)
+	for i := range r.fences {
+		vk.DestroyFence(v.Device, r.fences[i], nil)
+		vk.DestroySemaphore(v.Device, r.semaphores[i], nil)
+		vk.DestroySemaphore(v.Device, r.renderSemaphores[i], nil)
+	}
+	r.fences = nil
+	r.semaphores = nil
+	r.renderSemaphores = nil
+	r.imageFences = nil
+
 	vk.FreeCommandBuffers(v.Device, r.cmdPool, uint32(len(r.cmdBuffers)), r.cmdBuffers)
 	r.cmdBuffers = nil
 
 	vk.DestroyCommandPool(v.Device, r.cmdPool, nil)
 	vk.DestroyRenderPass(v.Device, r.RenderPass, nil)
+}
 
-	s.Destroy()
+func (v *VulkanDeviceInfo) destroy(b *VulkanBufferInfo, gfx *VulkanGfxPipelineInfo) {
 	gfx.Destroy()
 	b.Destroy()
-	vk.DestroyDevice(v.Device, nil)
-	if v.dbg != vk.NullDebugReportCallback {
-		vk.DestroyDebugReportCallback(v.Instance, v.dbg, nil)
-	}
-	vk.DestroyInstance(v.Instance, nil)
+	vk.DestroyDevice(v.Device, v.allocator)
+	v.destroyDebugMessenger()
+	v.destroySurface()
+	vk.DestroyInstance(v.Instance, v.allocator)
 }
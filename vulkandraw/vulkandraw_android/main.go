@@ -67,11 +67,20 @@ func main() {
 				case app.NativeWindowCreated:
 					err := vk.Init()
 					orPanic(err)
-					v, err = vulkandraw.NewVulkanDevice(appInfo, event.Window.Ptr())
+					window := event.Window.Ptr()
+					v, err = vulkandraw.NewVulkanDevice(appInfo, vulkandraw.DeviceOptions{
+						InstanceExtensions: vk.GetRequiredInstanceExtensions(),
+						CreateSurface: func(instance vk.Instance) (vk.Surface, error) {
+							var surface vk.Surface
+							err := vk.Error(vk.CreateWindowSurface(instance, window, nil, &surface))
+							return surface, err
+						},
+					})
 					orPanic(err)
 					s, err = v.CreateSwapchain()
 					orPanic(err)
-					r, err = vulkandraw.CreateRenderer(v.Device, s.DisplayFormat)
+					r, err = vulkandraw.CreateRenderer(v.Device, s.DisplayFormat,
+						vk.ImageLayoutPresentSrc, v.GraphicsQueueFamily)
 					orPanic(err)
 					err = s.CreateFramebuffers(r.RenderPass, vk.NullImageView)
 					orPanic(err)
@@ -91,7 +100,11 @@ func main() {
 					vulkandraw.DestroyInOrder(&v, &s, &r, &b, &gfx)
 				case app.NativeWindowRedrawNeeded:
 					if vkActive {
-						vulkandraw.VulkanDrawFrame(v, s, r)
+						if s.OutOfDate { // e.g. the device has been rotated
+							err := vulkandraw.VulkanRecreate(&v, &s, &r, &b, &gfx)
+							orPanic(err)
+						}
+						vulkandraw.VulkanDrawFrame(&v, &s, &r)
 					}
 					a.NativeWindowRedrawDone()
 				}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"flag"
+	"fmt"
 	"log"
 	"runtime"
 	"time"
@@ -19,11 +21,17 @@ var appInfo = &vk.ApplicationInfo{
 	PEngineName:        "vulkango.com\x00",
 }
 
+var deviceOverride = flag.String("device", vulkandraw.DeviceOverride,
+	"Index or name of the physical device to use, the best one is picked by default.")
+var validation = flag.Bool("validation", vulkandraw.EnableValidation,
+	"Enable the validation layers and log their warnings and errors.")
+
 func init() {
 	runtime.LockOSThread()
 }
 
 func main() {
+	flag.Parse()
 	procAddr := glfw.GetVulkanGetInstanceProcAddress()
 	if procAddr == nil {
 		panic("GetInstanceProcAddress is nil")
@@ -43,24 +51,40 @@ func main() {
 	)
 
 	glfw.WindowHint(glfw.ClientAPI, glfw.NoAPI)
-	glfw.WindowHint(glfw.Resizable, glfw.False)
+	glfw.WindowHint(glfw.Resizable, glfw.True)
 	window, err := glfw.CreateWindow(640, 480, "Vulkan Info", nil, nil)
 	orPanic(err)
+	window.SetFramebufferSizeCallback(func(w *glfw.Window, width int, height int) {
+		s.OutOfDate = true
+	})
+	var takeScreenshot bool
+	window.SetKeyCallback(func(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+		if key == glfw.KeyF12 && action == glfw.Press {
+			if err := s.CaptureNextFrame(); err != nil {
+				log.Println("[WARN] screenshot failed:", err)
+				return
+			}
+			takeScreenshot = true
+		}
+	})
 
-	createSurface := func(instance interface{}) uintptr {
-		surface, err := window.CreateWindowSurface(instance, nil)
-		orPanic(err)
-		return surface
-	}
-
-	v, err = vulkandraw.NewVulkanDevice(appInfo,
-		uintptr(window.Handle()),
-		window.GetRequiredInstanceExtensions(),
-		createSurface)
+	v, err = vulkandraw.NewVulkanDevice(appInfo, vulkandraw.DeviceOptions{
+		InstanceExtensions: window.GetRequiredInstanceExtensions(),
+		CreateSurface: func(instance vk.Instance) (vk.Surface, error) {
+			surface, err := window.CreateWindowSurface(instance, nil)
+			if err != nil {
+				return vk.NullSurface, err
+			}
+			return vk.SurfaceFromPointer(surface), nil
+		},
+		Device:     *deviceOverride,
+		Validation: *validation,
+	})
 	orPanic(err)
 	s, err = v.CreateSwapchain()
 	orPanic(err)
-	r, err = vulkandraw.CreateRenderer(v.Device, s.DisplayFormat)
+	r, err = vulkandraw.CreateRenderer(v.Device, s.DisplayFormat,
+		vk.ImageLayoutPresentSrc, v.GraphicsQueueFamily)
 	orPanic(err)
 	err = s.CreateFramebuffers(r.RenderPass, nil)
 	orPanic(err)
@@ -97,8 +121,25 @@ func main() {
 				continue
 			}
 			glfw.PollEvents()
-			if window.GetAttrib(glfw.Iconified) != 1 {
-				vulkandraw.VulkanDrawFrame(v, s, r)
+			if window.GetAttrib(glfw.Iconified) == 1 {
+				continue
+			}
+			if s.OutOfDate {
+				if width, height := window.GetFramebufferSize(); width == 0 || height == 0 {
+					continue
+				}
+				err = vulkandraw.VulkanRecreate(&v, &s, &r, &b, &gfx)
+				orPanic(err)
+			}
+			vulkandraw.VulkanDrawFrame(&v, &s, &r)
+			if takeScreenshot && !s.Capturing() {
+				takeScreenshot = false
+				name := fmt.Sprintf("vulkandraw-%s.png", time.Now().Format("20060102-150405"))
+				if err := s.Screenshot(name); err != nil {
+					log.Println("[WARN] screenshot failed:", err)
+				} else {
+					log.Println("[INFO] saved", name)
+				}
 			}
 		}
 	}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"time"
+
+	"github.com/vulkan-go/demos/vulkandraw"
+	vk "github.com/vulkan-go/vulkan"
+)
+
+var appInfo = &vk.ApplicationInfo{
+	SType:              vk.StructureTypeApplicationInfo,
+	ApiVersion:         vk.MakeVersion(1, 0, 0),
+	ApplicationVersion: vk.MakeVersion(1, 0, 0),
+	PApplicationName:   "VulkanDraw\x00",
+	PEngineName:        "vulkango.com\x00",
+}
+
+var (
+	width          = flag.Uint("width", 640, "Width of the offscreen images.")
+	height         = flag.Uint("height", 480, "Height of the offscreen images.")
+	frames         = flag.Int("frames", 60, "Number of frames to render before exiting.")
+	screenshot     = flag.String("o", "", "Save the last frame as a PNG file.")
+	deviceOverride = flag.String("device", vulkandraw.DeviceOverride,
+		"Index or name of the physical device to use, the best one is picked by default.")
+	validation = flag.Bool("validation", vulkandraw.EnableValidation,
+		"Enable the validation layers and log their warnings and errors.")
+)
+
+// offscreenImages is the number of images rendered into in turn, like a triple-buffered swapchain.
+const offscreenImages = 3
+
+func main() {
+	flag.Parse()
+	orPanic(vk.SetDefaultGetInstanceProcAddr())
+	orPanic(vk.Init())
+
+	var (
+		v   vulkandraw.VulkanDeviceInfo
+		o   vulkandraw.VulkanOffscreenInfo
+		r   vulkandraw.VulkanRenderInfo
+		b   vulkandraw.VulkanBufferInfo
+		gfx vulkandraw.VulkanGfxPipelineInfo
+	)
+
+	v, err := vulkandraw.NewVulkanDevice(appInfo, vulkandraw.DeviceOptions{
+		Device:     *deviceOverride,
+		Validation: *validation,
+	})
+	orPanic(err)
+	o, err = v.CreateOffscreen(uint32(*width), uint32(*height), offscreenImages)
+	orPanic(err)
+	r, err = vulkandraw.CreateRenderer(v.Device, o.DisplayFormat,
+		vk.ImageLayoutColorAttachmentOptimal, v.GraphicsQueueFamily)
+	orPanic(err)
+	err = o.CreateFramebuffers(r.RenderPass)
+	orPanic(err)
+	b, err = v.CreateBuffers()
+	orPanic(err)
+	gfx, err = vulkandraw.CreateGraphicsPipeline(v.Device, o.DisplaySize, r.RenderPass)
+	orPanic(err)
+	err = r.CreateCommandBuffers(o.ImagesLen())
+	orPanic(err)
+	vulkandraw.VulkanInitOffscreen(&v, &o, &r, &b, &gfx)
+
+	start := time.Now()
+	for i := 0; i < *frames; i++ {
+		orPanic(vulkandraw.VulkanDrawOffscreenFrame(&v, &o, &r))
+	}
+	orPanic(vk.DeviceWaitIdle(v.Device))
+	log.Printf("[INFO] rendered %d frames in %s", *frames, time.Since(start))
+	if len(*screenshot) > 0 {
+		err = o.Screenshot(*screenshot)
+		orPanic(err)
+		log.Println("[INFO] saved", *screenshot)
+	}
+
+	vulkandraw.DestroyOffscreenInOrder(&v, &o, &r, &b, &gfx)
+}
+
+func orPanic(err interface{}) {
+	switch v := err.(type) {
+	case error:
+		if v != nil {
+			panic(err)
+		}
+	case vk.Result:
+		if err := vk.Error(v); err != nil {
+			panic(err)
+		}
+	case bool:
+		if !v {
+			panic("condition failed: != true")
+		}
+	}
+}
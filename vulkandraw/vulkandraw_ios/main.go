@@ -41,11 +41,20 @@ func main() {
 				case app.ViewDidLoad:
 					err := vk.Init()
 					orPanic(err)
-					v, err = vulkandraw.NewVulkanDevice(appInfo, event.View)
+					view := event.View
+					v, err = vulkandraw.NewVulkanDevice(appInfo, vulkandraw.DeviceOptions{
+						InstanceExtensions: vk.GetRequiredInstanceExtensions(),
+						CreateSurface: func(instance vk.Instance) (vk.Surface, error) {
+							var surface vk.Surface
+							err := vk.Error(vk.CreateWindowSurface(instance, view, nil, &surface))
+							return surface, err
+						},
+					})
 					orPanic(err)
 					s, err = v.CreateSwapchain()
 					orPanic(err)
-					r, err = vulkandraw.CreateRenderer(v.Device, s.DisplayFormat)
+					r, err = vulkandraw.CreateRenderer(v.Device, s.DisplayFormat,
+						vk.ImageLayoutPresentSrc, v.GraphicsQueueFamily)
 					orPanic(err)
 					err = s.CreateFramebuffers(r.RenderPass, vk.NullImageView)
 					orPanic(err)
@@ -68,7 +77,11 @@ func main() {
 				}
 			case <-a.VSync():
 				if vkActive {
-					vulkandraw.VulkanDrawFrame(v, s, r)
+					if s.OutOfDate {
+						err := vulkandraw.VulkanRecreate(&v, &s, &r, &b, &gfx)
+						orPanic(err)
+					}
+					vulkandraw.VulkanDrawFrame(&v, &s, &r)
 				}
 			}
 		}
@@ -0,0 +1,143 @@
+package vulkandraw
+
+import (
+	"errors"
+	"image"
+	"path/filepath"
+	"testing"
+
+	"github.com/vulkan-go/demos/internal/golden"
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// softwareDevice is picked unless VULKANDRAW_DEVICE says otherwise, so the reference images
+// don't depend on the GPU of the machine. Set VULKANDRAW_DEVICE=SwiftShader to use SwiftShader.
+const softwareDevice = "llvmpipe"
+
+// renderOffscreen draws a few frames of the triangle headlessly and reads the last one back,
+// the test is skipped when there is no Vulkan loader or no suitable device.
+func renderOffscreen(t *testing.T, width, height uint32) *image.RGBA {
+	golden.InitVulkan(t)
+	opts := DeviceOptions{
+		Device: DeviceOverride,
+	}
+	if len(opts.Device) == 0 {
+		opts.Device = softwareDevice
+	}
+	appInfo := &vk.ApplicationInfo{
+		SType:              vk.StructureTypeApplicationInfo,
+		ApiVersion:         vk.MakeVersion(1, 0, 0),
+		ApplicationVersion: vk.MakeVersion(1, 0, 0),
+		PApplicationName:   "VulkanDraw\x00",
+		PEngineName:        "vulkango.com\x00",
+	}
+	v, err := NewVulkanDevice(appInfo, opts)
+	if errors.Is(err, ErrNoDevice) {
+		t.Skipf("no software Vulkan device: %s", err)
+	} else if err != nil {
+		t.Fatal(err)
+	}
+	var (
+		o   VulkanOffscreenInfo
+		r   VulkanRenderInfo
+		b   VulkanBufferInfo
+		gfx VulkanGfxPipelineInfo
+	)
+	o, err = v.CreateOffscreen(width, height, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err = CreateRenderer(v.Device, o.DisplayFormat,
+		vk.ImageLayoutColorAttachmentOptimal, v.GraphicsQueueFamily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := o.CreateFramebuffers(r.RenderPass); err != nil {
+		t.Fatal(err)
+	}
+	b, err = v.CreateBuffers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	gfx, err = CreateGraphicsPipeline(v.Device, o.DisplaySize, r.RenderPass)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.CreateCommandBuffers(o.ImagesLen()); err != nil {
+		t.Fatal(err)
+	}
+	VulkanInitOffscreen(&v, &o, &r, &b, &gfx)
+	defer DestroyOffscreenInOrder(&v, &o, &r, &b, &gfx)
+	for i := 0; i < 3; i++ {
+		if !VulkanDrawOffscreenFrame(&v, &o, &r) {
+			t.Fatal("VulkanDrawOffscreenFrame failed")
+		}
+	}
+	img, err := o.ReadFramebuffer()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func TestTriangle(t *testing.T) {
+	img := renderOffscreen(t, 64, 64)
+	golden.Check(t, img, filepath.Join("testdata", "triangle.png"), 2)
+}
+
+func TestCreateOffscreenZero(t *testing.T) {
+	// the sizes are checked before any Vulkan call, no device is needed
+	var v VulkanDeviceInfo
+	for _, size := range [][3]uint32{{0, 64, 2}, {64, 0, 2}, {64, 64, 0}} {
+		if _, err := v.CreateOffscreen(size[0], size[1], size[2]); err == nil {
+			t.Errorf("CreateOffscreen(%d, %d, %d) succeeded", size[0], size[1], size[2])
+		}
+	}
+}
+
+func TestMatchesOverride(t *testing.T) {
+	tests := []struct {
+		override string
+		index    int
+		name     string
+		want     bool
+	}{
+		{"", 0, "NVIDIA GeForce GTX 1080", true},
+		{"1", 1, "llvmpipe (LLVM 15.0.7, 256 bits)", true},
+		// a number is an index only, even if a name contains it
+		{"1", 0, "NVIDIA GeForce GTX 1080", false},
+		{"15", 1, "llvmpipe (LLVM 15.0.7, 256 bits)", false},
+		{"geforce", 0, "NVIDIA GeForce GTX 1080", true},
+		{"LLVMPIPE", 1, "llvmpipe (LLVM 15.0.7, 256 bits)", true},
+		{"swiftshader", 1, "llvmpipe (LLVM 15.0.7, 256 bits)", false},
+		{"GTX 1080", 0, "NVIDIA GeForce GTX 1080", true},
+	}
+	for _, test := range tests {
+		if got := matchesOverride(test.override, test.index, test.name); got != test.want {
+			t.Errorf("matchesOverride(%q, %d, %q) = %v, want %v",
+				test.override, test.index, test.name, got, test.want)
+		}
+	}
+}
+
+func TestPretransform(t *testing.T) {
+	vertices := []float32{1, 0, 0.5, 0, 1, 0.5}
+	tests := []struct {
+		transform vk.SurfaceTransformFlagBits
+		want      []float32
+	}{
+		{vk.SurfaceTransformIdentityBit, []float32{1, 0, 0.5, 0, 1, 0.5}},
+		{vk.SurfaceTransformRotate90Bit, []float32{0, 1, 0.5, -1, 0, 0.5}},
+		{vk.SurfaceTransformRotate180Bit, []float32{-1, 0, 0.5, 0, -1, 0.5}},
+		{vk.SurfaceTransformRotate270Bit, []float32{0, -1, 0.5, 1, 0, 0.5}},
+	}
+	for _, test := range tests {
+		got := pretransform(vertices, test.transform)
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("transform %d: got %v, want %v", test.transform, got, test.want)
+				break
+			}
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package vulkaninfo
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/xlab/tablewriter"
+)
+
+// Aggregate holds statistics over the devices of many reports,
+// for all of them together and for each vendor ID separately.
+type Aggregate struct {
+	Reports int               `json:"reports"`
+	All     *AggregateGroup   `json:"all"`
+	Vendors []*AggregateGroup `json:"vendors"`
+}
+
+// AggregateGroup summarizes a set of devices. VendorID is zero for the group of all devices.
+type AggregateGroup struct {
+	VendorID       uint32       `json:"vendorID,omitempty"`
+	VendorName     string       `json:"vendorName,omitempty"`
+	Devices        int          `json:"devices"`
+	Extensions     []Support    `json:"extensions"`
+	FormatFeatures []Support    `json:"formatFeatures"`
+	Limits         []LimitRange `json:"limits"`
+
+	extensions     map[string]int
+	formatFeatures map[string]int
+	limitMin       reflect.Value
+	limitMax       reflect.Value
+}
+
+// Support tells how many devices of a group support an extension or a format feature.
+type Support struct {
+	Name    string  `json:"name"`
+	Devices int     `json:"devices"`
+	Percent float64 `json:"percent"`
+}
+
+// LimitRange is the smallest and the largest value of a limit within a group,
+// array limits are compared component-wise.
+type LimitRange struct {
+	Name string      `json:"name"`
+	Min  interface{} `json:"min"`
+	Max  interface{} `json:"max"`
+}
+
+// LoadReports reads every *.json report in the directory, in file name order.
+func LoadReports(dir string) ([]*Report, error) {
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var reports []*Report
+	for _, f := range files {
+		if f.IsDir() || filepath.Ext(f.Name()) != ".json" {
+			continue
+		}
+		r, err := LoadReport(filepath.Join(dir, f.Name()))
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, r)
+	}
+	return reports, nil
+}
+
+// AggregateReports computes the statistics over every device of the reports.
+func AggregateReports(reports []*Report) *Aggregate {
+	a := &Aggregate{
+		Reports: len(reports),
+		All:     newAggregateGroup(0),
+	}
+	vendors := make(map[uint32]*AggregateGroup)
+	for _, r := range reports {
+		for _, d := range r.Devices {
+			g, ok := vendors[d.VendorID]
+			if !ok {
+				g = newAggregateGroup(d.VendorID)
+				vendors[d.VendorID] = g
+				a.Vendors = append(a.Vendors, g)
+			}
+			a.All.add(d)
+			g.add(d)
+		}
+	}
+	sort.Slice(a.Vendors, func(i, j int) bool {
+		return a.Vendors[i].VendorID < a.Vendors[j].VendorID
+	})
+	a.All.finish()
+	for _, g := range a.Vendors {
+		g.finish()
+	}
+	return a
+}
+
+func newAggregateGroup(vendorID uint32) *AggregateGroup {
+	g := &AggregateGroup{
+		VendorID:       vendorID,
+		extensions:     make(map[string]int),
+		formatFeatures: make(map[string]int),
+	}
+	if vendorID != 0 {
+		g.VendorName = vendorName(vendorID)
+	}
+	return g
+}
+
+func (g *AggregateGroup) add(d *DeviceReport) {
+	g.Devices++
+	for _, ext := range d.Extensions {
+		g.extensions[ext.Name]++
+	}
+	for _, f := range d.Formats {
+		for _, feature := range f.LinearTilingFeatures {
+			g.formatFeatures[f.Format+" linear "+feature]++
+		}
+		for _, feature := range f.OptimalTilingFeatures {
+			g.formatFeatures[f.Format+" optimal "+feature]++
+		}
+		for _, feature := range f.BufferFeatures {
+			g.formatFeatures[f.Format+" buffer "+feature]++
+		}
+	}
+
+	limits := reflect.ValueOf(d.Limits)
+	if !g.limitMin.IsValid() {
+		g.limitMin = reflect.New(limits.Type()).Elem()
+		g.limitMin.Set(limits)
+		g.limitMax = reflect.New(limits.Type()).Elem()
+		g.limitMax.Set(limits)
+		return
+	}
+	for i := 0; i < limits.NumField(); i++ {
+		updateRange(g.limitMin.Field(i), g.limitMax.Field(i), limits.Field(i))
+	}
+}
+
+// updateRange lowers min and raises max so they include the value.
+func updateRange(min, max, v reflect.Value) {
+	switch v.Kind() {
+	case reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			updateRange(min.Index(i), max.Index(i), v.Index(i))
+		}
+	case reflect.Bool:
+		if !v.Bool() {
+			min.SetBool(false)
+		} else {
+			max.SetBool(true)
+		}
+	case reflect.Uint32, reflect.Uint64:
+		if v.Uint() < min.Uint() {
+			min.SetUint(v.Uint())
+		}
+		if v.Uint() > max.Uint() {
+			max.SetUint(v.Uint())
+		}
+	case reflect.Int32:
+		if v.Int() < min.Int() {
+			min.SetInt(v.Int())
+		}
+		if v.Int() > max.Int() {
+			max.SetInt(v.Int())
+		}
+	case reflect.Float32:
+		if v.Float() < min.Float() {
+			min.SetFloat(v.Float())
+		}
+		if v.Float() > max.Float() {
+			max.SetFloat(v.Float())
+		}
+	}
+}
+
+func (g *AggregateGroup) finish() {
+	g.Extensions = supportList(g.extensions, g.Devices)
+	g.FormatFeatures = supportList(g.formatFeatures, g.Devices)
+	if !g.limitMin.IsValid() {
+		return
+	}
+	rt := g.limitMin.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		g.Limits = append(g.Limits, LimitRange{
+			Name: strings.Split(rt.Field(i).Tag.Get("json"), ",")[0],
+			Min:  g.limitMin.Field(i).Interface(),
+			Max:  g.limitMax.Field(i).Interface(),
+		})
+	}
+}
+
+// supportList sorts the counts by descending support, then by name.
+func supportList(counts map[string]int, devices int) []Support {
+	list := make([]Support, 0, len(counts))
+	for name, n := range counts {
+		list = append(list, Support{
+			Name:    name,
+			Devices: n,
+			Percent: 100 * float64(n) / float64(devices),
+		})
+	}
+	sort.Slice(list, func(i, j int) bool {
+		if list[i].Devices != list[j].Devices {
+			return list[i].Devices > list[j].Devices
+		}
+		return list[i].Name < list[j].Name
+	})
+	return list
+}
+
+// FprintAggregate renders the statistics as a table to w.
+func FprintAggregate(w io.Writer, a *Aggregate) error {
+	table := tablewriter.CreateTable()
+	table.UTF8Box()
+	table.AddTitle("VULKAN CAPABILITIES")
+	table.AddRow("Reports", a.Reports)
+	table.AddRow("Physical GPUs", a.All.Devices)
+	table.AddSeparator()
+
+	printAggregateGroup(table, "ALL VENDORS", a.All)
+	for _, g := range a.Vendors {
+		table.AddSeparator()
+		printAggregateGroup(table, fmt.Sprintf("VENDOR %s (%x)", g.VendorName, g.VendorID), g)
+	}
+
+	_, err := fmt.Fprintln(w, "\n\n"+table.Render())
+	return err
+}
+
+func printAggregateGroup(table *tablewriter.Table, title string, g *AggregateGroup) {
+	table.AddRow(title, fmt.Sprintf("%d devices", g.Devices))
+	table.AddRow("EXTENSIONS", "")
+	for _, s := range g.Extensions {
+		table.AddRow(s.Name, fmt.Sprintf("%.1f%%", s.Percent))
+	}
+	table.AddRow("FORMAT FEATURES", "")
+	for _, s := range g.FormatFeatures {
+		table.AddRow(s.Name, fmt.Sprintf("%.1f%%", s.Percent))
+	}
+	table.AddRow("LIMITS", "min - max")
+	for _, l := range g.Limits {
+		table.AddRow(l.Name, fmt.Sprintf("%v - %v", l.Min, l.Max))
+	}
+}
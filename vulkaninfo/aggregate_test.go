@@ -0,0 +1,98 @@
+package vulkaninfo
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAggregateReports(t *testing.T) {
+	nvidia := func(maxImage uint32, workGroup [3]uint32, extensions ...string) *DeviceReport {
+		d := &DeviceReport{VendorID: 0x10de, Formats: []FormatFeatures{{
+			Format:                "R8g8b8a8Unorm",
+			OptimalTilingFeatures: []string{"SampledImage", "ColorAttachment"},
+		}}}
+		d.Limits.MaxImageDimension2D = maxImage
+		d.Limits.MaxComputeWorkGroupSize = workGroup
+		d.Limits.StrictLines = true
+		for _, name := range extensions {
+			d.Extensions = append(d.Extensions, Extension{Name: name})
+		}
+		return d
+	}
+	llvmpipe := &DeviceReport{VendorID: 0x10005, Extensions: []Extension{{Name: "VK_KHR_swapchain"}}}
+	llvmpipe.Limits.MaxImageDimension2D = 16384
+	llvmpipe.Limits.MaxComputeWorkGroupSize = [3]uint32{1024, 1024, 1024}
+
+	reports := []*Report{
+		{Devices: []*DeviceReport{
+			nvidia(32768, [3]uint32{1024, 1024, 64}, "VK_KHR_swapchain", "VK_KHR_ray_query"),
+			llvmpipe,
+		}},
+		{Devices: []*DeviceReport{
+			nvidia(16384, [3]uint32{512, 1024, 64}, "VK_KHR_swapchain"),
+		}},
+	}
+	a := AggregateReports(reports)
+
+	if a.Reports != 2 || a.All.Devices != 3 {
+		t.Fatalf("got %d reports and %d devices, want 2 and 3", a.Reports, a.All.Devices)
+	}
+	if len(a.Vendors) != 2 || a.Vendors[0].VendorID != 0x10de || a.Vendors[1].VendorID != 0x10005 {
+		t.Fatalf("vendor groups are not sorted by vendor ID: %+v", a.Vendors)
+	}
+
+	tests := []struct {
+		name  string
+		group *AggregateGroup
+		list  []Support
+		want  []Support
+	}{
+		{"all extensions", a.All, a.All.Extensions, []Support{
+			{"VK_KHR_swapchain", 3, 100},
+			{"VK_KHR_ray_query", 1, 100.0 / 3},
+		}},
+		{"nvidia extensions", a.Vendors[0], a.Vendors[0].Extensions, []Support{
+			{"VK_KHR_swapchain", 2, 100},
+			{"VK_KHR_ray_query", 1, 50},
+		}},
+		{"all format features", a.All, a.All.FormatFeatures, []Support{
+			{"R8g8b8a8Unorm optimal ColorAttachment", 2, 200.0 / 3},
+			{"R8g8b8a8Unorm optimal SampledImage", 2, 200.0 / 3},
+		}},
+		{"llvmpipe format features", a.Vendors[1], a.Vendors[1].FormatFeatures, []Support{}},
+	}
+	for _, test := range tests {
+		if !reflect.DeepEqual(test.list, test.want) {
+			t.Errorf("%s: got %v, want %v", test.name, test.list, test.want)
+		}
+	}
+
+	limits := []struct {
+		group    *AggregateGroup
+		name     string
+		min, max interface{}
+	}{
+		{a.All, "maxImageDimension2D", uint32(16384), uint32(32768)},
+		{a.All, "maxComputeWorkGroupSize", [3]uint32{512, 1024, 64}, [3]uint32{1024, 1024, 1024}},
+		{a.All, "strictLines", false, true},
+		{a.Vendors[0], "maxImageDimension2D", uint32(16384), uint32(32768)},
+		{a.Vendors[0], "strictLines", true, true},
+		{a.Vendors[1], "maxImageDimension2D", uint32(16384), uint32(16384)},
+	}
+	for _, test := range limits {
+		var got *LimitRange
+		for i := range test.group.Limits {
+			if test.group.Limits[i].Name == test.name {
+				got = &test.group.Limits[i]
+			}
+		}
+		if got == nil {
+			t.Errorf("vendor %x: no limit %s", test.group.VendorID, test.name)
+			continue
+		}
+		if !reflect.DeepEqual(got.Min, test.min) || !reflect.DeepEqual(got.Max, test.max) {
+			t.Errorf("vendor %x: %s is %v - %v, want %v - %v", test.group.VendorID,
+				test.name, got.Min, got.Max, test.min, test.max)
+		}
+	}
+}
@@ -0,0 +1,363 @@
+package vulkaninfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// ChangeKind tells whether an item was added, removed or changed between two reports.
+type ChangeKind int
+
+const (
+	ChangeAdded ChangeKind = iota
+	ChangeRemoved
+	ChangeChanged
+)
+
+func (k ChangeKind) String() string {
+	switch k {
+	case ChangeAdded:
+		return "+"
+	case ChangeRemoved:
+		return "-"
+	default:
+		return "~"
+	}
+}
+
+// Difference is a single change between two reports.
+type Difference struct {
+	Kind  ChangeKind
+	Scope string // "instance" or "device #N"
+	What  string // extension, layer, limit, format, ...
+	Name  string
+	Old   string // only set for ChangeChanged
+	New   string // only set for ChangeChanged
+}
+
+func (d Difference) String() string {
+	if d.Kind == ChangeChanged {
+		return fmt.Sprintf("%s %s %s %s: %s -> %s", d.Kind, d.Scope, d.What, d.Name, d.Old, d.New)
+	}
+	return fmt.Sprintf("%s %s %s %s", d.Kind, d.Scope, d.What, d.Name)
+}
+
+// ReadJSON loads a report previously serialized with WriteJSON.
+func ReadJSON(r io.Reader) (*Report, error) {
+	var report Report
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// LoadReport reads a JSON report from the file.
+func LoadReport(path string) (*Report, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	report, err := ReadJSON(f)
+	if err != nil {
+		err = fmt.Errorf("failed to read report %s: %s", path, err)
+		return nil, err
+	}
+	return report, nil
+}
+
+// DiffReports lists what changed from report a to report b. Devices are paired
+// by vendor ID, device ID and name first, so reordered GPUs are not reported as changed,
+// the remaining ones are compared in the order they appear in the reports.
+func DiffReports(a, b *Report) []Difference {
+	var diffs []Difference
+	diffs = append(diffs, diffExtensions("instance", "extension", a.InstanceExtensions, b.InstanceExtensions)...)
+	diffs = append(diffs, diffLayers("instance", a.InstanceLayers, b.InstanceLayers)...)
+
+	pairs := pairDevices(a.Devices, b.Devices)
+	paired := make(map[*DeviceReport]bool, len(pairs))
+	for _, db := range b.Devices {
+		scope := fmt.Sprintf("device #%d", db.Index)
+		da, ok := pairs[db]
+		if !ok {
+			diffs = append(diffs, Difference{
+				Kind: ChangeAdded, Scope: scope, What: "device", Name: db.DeviceName,
+			})
+			continue
+		}
+		paired[da] = true
+		diffs = append(diffs, diffDevices(scope, da, db)...)
+	}
+	for _, da := range a.Devices {
+		if !paired[da] {
+			diffs = append(diffs, Difference{
+				Kind:  ChangeRemoved,
+				Scope: fmt.Sprintf("device #%d", da.Index),
+				What:  "device",
+				Name:  da.DeviceName,
+			})
+		}
+	}
+	return diffs
+}
+
+// pairDevices maps the devices of b to the devices of a they are compared with.
+func pairDevices(a, b []*DeviceReport) map[*DeviceReport]*DeviceReport {
+	pairs := make(map[*DeviceReport]*DeviceReport, len(b))
+	used := make(map[*DeviceReport]bool, len(a))
+	for _, db := range b {
+		for _, da := range a {
+			if !used[da] && da.VendorID == db.VendorID &&
+				da.DeviceID == db.DeviceID && da.DeviceName == db.DeviceName {
+				pairs[db] = da
+				used[da] = true
+				break
+			}
+		}
+	}
+	// the unmatched devices are paired in order, e.g. a GPU has been swapped
+	var restA []*DeviceReport
+	for _, da := range a {
+		if !used[da] {
+			restA = append(restA, da)
+		}
+	}
+	for _, db := range b {
+		if _, ok := pairs[db]; !ok && len(restA) > 0 {
+			pairs[db] = restA[0]
+			restA = restA[1:]
+		}
+	}
+	return pairs
+}
+
+// PrintDiff writes a line per difference.
+func PrintDiff(w io.Writer, diffs []Difference) {
+	for _, d := range diffs {
+		fmt.Fprintln(w, d)
+	}
+}
+
+func diffDevices(scope string, a, b *DeviceReport) []Difference {
+	var diffs []Difference
+	changed := func(what, name, old, new string) {
+		if old != new {
+			diffs = append(diffs, Difference{
+				Kind: ChangeChanged, Scope: scope, What: what, Name: name, Old: old, New: new,
+			})
+		}
+	}
+	changed("property", "DeviceName", a.DeviceName, b.DeviceName)
+	changed("property", "VendorID", fmt.Sprintf("%x", a.VendorID), fmt.Sprintf("%x", b.VendorID))
+	changed("property", "DeviceID", fmt.Sprintf("%x", a.DeviceID), fmt.Sprintf("%x", b.DeviceID))
+	changed("property", "DeviceType", a.DeviceType, b.DeviceType)
+	changed("version", "APIVersion", a.APIVersion, b.APIVersion)
+	changed("version", "DriverVersion", a.DriverVersion, b.DriverVersion)
+
+	diffs = append(diffs, diffExtensions(scope, "extension", a.Extensions, b.Extensions)...)
+	diffs = append(diffs, diffLayers(scope, a.Layers, b.Layers)...)
+	diffs = append(diffs, diffFields(scope, "limit", a.Limits, b.Limits)...)
+	diffs = append(diffs, diffFields(scope, "sparse property", a.SparseProperties, b.SparseProperties)...)
+	diffs = append(diffs, diffOptional(scope, "Vulkan 1.1 feature", a.Vulkan11Features, b.Vulkan11Features)...)
+	diffs = append(diffs, diffOptional(scope, "Vulkan 1.2 feature", a.Vulkan12Features, b.Vulkan12Features)...)
+	diffs = append(diffs, diffOptional(scope, "Vulkan 1.3 feature", a.Vulkan13Features, b.Vulkan13Features)...)
+	diffs = append(diffs, diffOptional(scope, "subgroup property", a.Subgroup, b.Subgroup)...)
+	diffs = append(diffs, diffOptional(scope, "driver property", a.Driver, b.Driver)...)
+	diffs = append(diffs, diffOptional(scope, "descriptor indexing", a.DescriptorIndexing, b.DescriptorIndexing)...)
+	diffs = append(diffs, diffOptional(scope, "surface capability", a.Surface, b.Surface)...)
+	diffs = append(diffs, diffList(scope, "memory heap", a.MemoryHeaps, b.MemoryHeaps)...)
+	diffs = append(diffs, diffList(scope, "memory type", a.MemoryTypes, b.MemoryTypes)...)
+	diffs = append(diffs, diffList(scope, "queue family", a.QueueFamilies, b.QueueFamilies)...)
+
+	formatsA := make(map[string]FormatFeatures, len(a.Formats))
+	for _, f := range a.Formats {
+		formatsA[f.Format] = f
+	}
+	formatsB := make(map[string]FormatFeatures, len(b.Formats))
+	for _, f := range b.Formats {
+		formatsB[f.Format] = f
+	}
+	for _, fa := range a.Formats {
+		fb, ok := formatsB[fa.Format]
+		if !ok {
+			diffs = append(diffs, Difference{
+				Kind: ChangeRemoved, Scope: scope, What: "format", Name: fa.Format,
+			})
+			continue
+		}
+		changed("format", fa.Format+" linear tiling",
+			joinFlags(fa.LinearTilingFeatures), joinFlags(fb.LinearTilingFeatures))
+		changed("format", fa.Format+" optimal tiling",
+			joinFlags(fa.OptimalTilingFeatures), joinFlags(fb.OptimalTilingFeatures))
+		changed("format", fa.Format+" buffer",
+			joinFlags(fa.BufferFeatures), joinFlags(fb.BufferFeatures))
+	}
+	for _, fb := range b.Formats {
+		if _, ok := formatsA[fb.Format]; !ok {
+			diffs = append(diffs, Difference{
+				Kind: ChangeAdded, Scope: scope, What: "format", Name: fb.Format,
+			})
+		}
+	}
+	return diffs
+}
+
+func diffNames(scope, what string, a, b []string) []Difference {
+	var diffs []Difference
+	inA := make(map[string]bool, len(a))
+	for _, name := range a {
+		inA[name] = true
+	}
+	inB := make(map[string]bool, len(b))
+	for _, name := range b {
+		inB[name] = true
+	}
+	for _, name := range a {
+		if !inB[name] {
+			diffs = append(diffs, Difference{
+				Kind: ChangeRemoved, Scope: scope, What: what, Name: name,
+			})
+		}
+	}
+	for _, name := range b {
+		if !inA[name] {
+			diffs = append(diffs, Difference{
+				Kind: ChangeAdded, Scope: scope, What: what, Name: name,
+			})
+		}
+	}
+	return diffs
+}
+
+// diffExtensions reports added and removed extensions as well as spec version bumps.
+func diffExtensions(scope, what string, a, b []Extension) []Difference {
+	diffs := diffNames(scope, what, extensionNames(a), extensionNames(b))
+	versions := make(map[string]uint32, len(a))
+	for _, ext := range a {
+		versions[ext.Name] = ext.SpecVersion
+	}
+	for _, ext := range b {
+		if old, ok := versions[ext.Name]; ok && old != ext.SpecVersion {
+			diffs = append(diffs, Difference{
+				Kind:  ChangeChanged,
+				Scope: scope,
+				What:  what,
+				Name:  ext.Name,
+				Old:   fmt.Sprintf("rev %d", old),
+				New:   fmt.Sprintf("rev %d", ext.SpecVersion),
+			})
+		}
+	}
+	return diffs
+}
+
+// diffLayers reports added and removed layers as well as version bumps.
+func diffLayers(scope string, a, b []Layer) []Difference {
+	var namesA, namesB []string
+	layersA := make(map[string]Layer, len(a))
+	for _, layer := range a {
+		namesA = append(namesA, layer.Name)
+		layersA[layer.Name] = layer
+	}
+	for _, layer := range b {
+		namesB = append(namesB, layer.Name)
+	}
+	diffs := diffNames(scope, "layer", namesA, namesB)
+	for _, lb := range b {
+		la, ok := layersA[lb.Name]
+		if !ok {
+			continue
+		}
+		old := fmt.Sprintf("%s (impl %d)", vk.Version(la.SpecVersion), la.ImplementationVersion)
+		new := fmt.Sprintf("%s (impl %d)", vk.Version(lb.SpecVersion), lb.ImplementationVersion)
+		if old != new {
+			diffs = append(diffs, Difference{
+				Kind: ChangeChanged, Scope: scope, What: "layer", Name: lb.Name, Old: old, New: new,
+			})
+		}
+		diffs = append(diffs, diffExtensions(scope, "layer "+lb.Name+" extension", la.Extensions, lb.Extensions)...)
+	}
+	return diffs
+}
+
+// diffOptional compares two struct pointers of the same type,
+// a nil pointer means the report has no such section.
+func diffOptional(scope, what string, a, b interface{}) []Difference {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch {
+	case va.IsNil() && vb.IsNil():
+		return nil
+	case va.IsNil():
+		return []Difference{{Kind: ChangeAdded, Scope: scope, What: "section", Name: what}}
+	case vb.IsNil():
+		return []Difference{{Kind: ChangeRemoved, Scope: scope, What: "section", Name: what}}
+	}
+	return diffFields(scope, what, va.Elem().Interface(), vb.Elem().Interface())
+}
+
+// diffList compares two slices of structs of the same type element by element,
+// e.g. the memory heaps or the queue families, which are identified by their index.
+func diffList(scope, what string, a, b interface{}) []Difference {
+	var diffs []Difference
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	for i := 0; i < va.Len() || i < vb.Len(); i++ {
+		name := fmt.Sprintf("#%d", i)
+		switch {
+		case i >= vb.Len():
+			diffs = append(diffs, Difference{Kind: ChangeRemoved, Scope: scope, What: what, Name: name})
+		case i >= va.Len():
+			diffs = append(diffs, Difference{Kind: ChangeAdded, Scope: scope, What: what, Name: name})
+		default:
+			diffs = append(diffs, diffFields(scope, what+" "+name, va.Index(i).Interface(), vb.Index(i).Interface())...)
+		}
+	}
+	return diffs
+}
+
+// diffFields compares two structs of the same type field by field.
+func diffFields(scope, what string, a, b interface{}) []Difference {
+	return diffNestedFields(scope, what, "", a, b)
+}
+
+// diffNestedFields compares the fields of nested structs too, their names are prefixed
+// with the path of the enclosing fields, e.g. CurrentExtent.Width.
+func diffNestedFields(scope, what, prefix string, a, b interface{}) []Difference {
+	var diffs []Difference
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	rt := va.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		name := prefix + rt.Field(i).Name
+		fa, fb := va.Field(i).Interface(), vb.Field(i).Interface()
+		if va.Field(i).Kind() == reflect.Struct {
+			diffs = append(diffs, diffNestedFields(scope, what, name+".", fa, fb)...)
+			continue
+		}
+		if !reflect.DeepEqual(fa, fb) {
+			diffs = append(diffs, Difference{
+				Kind:  ChangeChanged,
+				Scope: scope,
+				What:  what,
+				Name:  name,
+				Old:   formatField(va.Field(i)),
+				New:   formatField(vb.Field(i)),
+			})
+		}
+	}
+	return diffs
+}
+
+// formatField prints the value a pointer field points to, "n/a" if it is nil.
+func formatField(v reflect.Value) string {
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return "n/a"
+		}
+		v = v.Elem()
+	}
+	return fmt.Sprintf("%v", v.Interface())
+}
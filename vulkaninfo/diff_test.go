@@ -0,0 +1,92 @@
+package vulkaninfo
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestDiffReports(t *testing.T) {
+	a, err := LoadReport(filepath.Join("testdata", "diff_a.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := LoadReport(filepath.Join("testdata", "diff_b.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the devices are swapped in b, only the real changes of the GeForce are expected
+	want := []string{
+		"+ instance extension VK_EXT_debug_utils",
+		"~ device #1 version DriverVersion: 525.60.11 -> 535.54.3",
+		"+ device #1 memory heap #1",
+		"~ device #1 memory type #0 PropertyFlags: [DeviceLocal] -> [DeviceLocal HostVisible]",
+		"~ device #1 queue family #0 QueueCount: 16 -> 8",
+	}
+	diffs := DiffReports(a, b)
+	if len(diffs) != len(want) {
+		t.Fatalf("got %d differences, want %d: %v", len(diffs), len(want), diffs)
+	}
+	for i, d := range diffs {
+		if d.String() != want[i] {
+			t.Errorf("difference %d: got %q, want %q", i, d, want[i])
+		}
+	}
+
+	if diffs := DiffReports(a, a); len(diffs) > 0 {
+		t.Errorf("a report differs from itself: %v", diffs)
+	}
+}
+
+func TestDiffReportsDevices(t *testing.T) {
+	gpu := &DeviceReport{Index: 0, DeviceName: "GPU", VendorID: 0x10de, DeviceID: 0x1b80}
+	cpu := &DeviceReport{Index: 1, DeviceName: "llvmpipe", VendorID: 0x10005}
+	swapped := &DeviceReport{Index: 0, DeviceName: "Other GPU", VendorID: 0x1002, DeviceID: 0x73bf}
+
+	tests := []struct {
+		name string
+		a, b []*DeviceReport
+		want []string
+	}{
+		{"reordered", []*DeviceReport{gpu, cpu}, []*DeviceReport{
+			{Index: 0, DeviceName: "llvmpipe", VendorID: 0x10005},
+			{Index: 1, DeviceName: "GPU", VendorID: 0x10de, DeviceID: 0x1b80},
+		}, nil},
+		{"removed", []*DeviceReport{gpu, cpu}, []*DeviceReport{gpu}, []string{
+			"- device #1 device llvmpipe",
+		}},
+		{"added", []*DeviceReport{gpu}, []*DeviceReport{gpu, cpu}, []string{
+			"+ device #1 device llvmpipe",
+		}},
+		{"swapped", []*DeviceReport{gpu}, []*DeviceReport{swapped}, []string{
+			"~ device #0 property DeviceName: GPU -> Other GPU",
+			"~ device #0 property VendorID: 10de -> 1002",
+			"~ device #0 property DeviceID: 1b80 -> 73bf",
+		}},
+		{"nested", []*DeviceReport{{DeviceName: "GPU", Surface: &SurfaceReport{
+			CurrentExtent:  Extent2D{Width: 800, Height: 600},
+			MaxImageExtent: Extent2D{Width: 800, Height: 600},
+		}}}, []*DeviceReport{{DeviceName: "GPU", Surface: &SurfaceReport{
+			CurrentExtent:  Extent2D{Width: 1024, Height: 600},
+			MaxImageExtent: Extent2D{Width: 4096, Height: 600},
+		}}}, []string{
+			"~ device #0 surface capability CurrentExtent.Width: 800 -> 1024",
+			"~ device #0 surface capability MaxImageExtent.Width: 800 -> 4096",
+		}},
+	}
+	for _, test := range tests {
+		diffs := DiffReports(&Report{Devices: test.a}, &Report{Devices: test.b})
+		var got []string
+		for _, d := range diffs {
+			got = append(got, d.String())
+		}
+		if len(got) != len(test.want) {
+			t.Errorf("%s: got %q, want %q", test.name, got, test.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != test.want[i] {
+				t.Errorf("%s: got %q, want %q", test.name, got[i], test.want[i])
+			}
+		}
+	}
+}
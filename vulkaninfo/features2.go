@@ -0,0 +1,475 @@
+package vulkaninfo
+
+/*
+#include <stdint.h>
+#include <stdlib.h>
+
+typedef void (*vkVoidFunction)(void);
+
+// chainStruct is large enough to hold any of the queried structures,
+// their members are read back as 32-bit words in declaration order.
+typedef struct chainStruct {
+	uint32_t sType;
+	void*    pNext;
+	uint32_t data[256];
+} chainStruct;
+
+static uint32_t enumerateInstanceVersion(vkVoidFunction fn) {
+	typedef int32_t (*enumerateFunc)(uint32_t*);
+	uint32_t version = 0;
+	if (fn == NULL || ((enumerateFunc)fn)(&version) != 0) {
+		return 0;
+	}
+	return version;
+}
+
+static void callPhysicalDeviceFunc(vkVoidFunction fn, void* gpu, chainStruct* s) {
+	((void (*)(void*, chainStruct*))fn)(gpu, s);
+}
+*/
+import "C"
+
+import (
+	"fmt"
+	"reflect"
+	"unsafe"
+
+	"github.com/vulkan-go/demos/internal/vkproc"
+	vk "github.com/vulkan-go/vulkan"
+)
+
+const (
+	structureTypePhysicalDeviceVulkan11Features = 49
+	structureTypePhysicalDeviceVulkan12Features = 51
+	structureTypePhysicalDeviceVulkan13Features = 53
+)
+
+// InstanceVersion returns the highest instance API version supported by the loader,
+// Vulkan 1.0 loaders do not provide vkEnumerateInstanceVersion. Builds without the loader
+// (e.g. Android) can't resolve it either and fall back to Vulkan 1.0.
+func InstanceVersion() uint32 {
+	fn := C.vkVoidFunction(vkproc.InstanceProc(nil, "vkEnumerateInstanceVersion"))
+	if version := uint32(C.enumerateInstanceVersion(fn)); version != 0 {
+		return version
+	}
+	return vk.MakeVersion(1, 0, 0)
+}
+
+// Vulkan11Features mirrors VkPhysicalDeviceVulkan11Features, which can only be queried
+// from Vulkan 1.2 devices. Vulkan 1.1 devices report the same features in the individual
+// structures listed in vulkan11FeatureStructs.
+type Vulkan11Features struct {
+	StorageBuffer16BitAccess           bool `json:"storageBuffer16BitAccess"`
+	UniformAndStorageBuffer16BitAccess bool `json:"uniformAndStorageBuffer16BitAccess"`
+	StoragePushConstant16              bool `json:"storagePushConstant16"`
+	StorageInputOutput16               bool `json:"storageInputOutput16"`
+	Multiview                          bool `json:"multiview"`
+	MultiviewGeometryShader            bool `json:"multiviewGeometryShader"`
+	MultiviewTessellationShader        bool `json:"multiviewTessellationShader"`
+	VariablePointersStorageBuffer      bool `json:"variablePointersStorageBuffer"`
+	VariablePointers                   bool `json:"variablePointers"`
+	ProtectedMemory                    bool `json:"protectedMemory"`
+	SamplerYcbcrConversion             bool `json:"samplerYcbcrConversion"`
+	ShaderDrawParameters               bool `json:"shaderDrawParameters"`
+}
+
+// vulkan11FeatureStructs are the structures of the Vulkan 1.1 core features with the number
+// of members each one has, their members are the fields of Vulkan11Features in order.
+var vulkan11FeatureStructs = []struct {
+	sType   vk.StructureType
+	members int
+}{
+	{vk.StructureTypePhysicalDevice16bitStorageFeatures, 4},
+	{vk.StructureTypePhysicalDeviceMultiviewFeatures, 3},
+	{vk.StructureTypePhysicalDeviceVariablePointerFeatures, 2},
+	{vk.StructureTypePhysicalDeviceProtectedMemoryFeatures, 1},
+	{vk.StructureTypePhysicalDeviceSamplerYcbcrConversionFeatures, 1},
+	{vk.StructureTypePhysicalDeviceShaderDrawParameterFeatures, 1},
+}
+
+// Vulkan12Features mirrors VkPhysicalDeviceVulkan12Features.
+type Vulkan12Features struct {
+	SamplerMirrorClampToEdge                           bool `json:"samplerMirrorClampToEdge"`
+	DrawIndirectCount                                  bool `json:"drawIndirectCount"`
+	StorageBuffer8BitAccess                            bool `json:"storageBuffer8BitAccess"`
+	UniformAndStorageBuffer8BitAccess                  bool `json:"uniformAndStorageBuffer8BitAccess"`
+	StoragePushConstant8                               bool `json:"storagePushConstant8"`
+	ShaderBufferInt64Atomics                           bool `json:"shaderBufferInt64Atomics"`
+	ShaderSharedInt64Atomics                           bool `json:"shaderSharedInt64Atomics"`
+	ShaderFloat16                                      bool `json:"shaderFloat16"`
+	ShaderInt8                                         bool `json:"shaderInt8"`
+	DescriptorIndexing                                 bool `json:"descriptorIndexing"`
+	ShaderInputAttachmentArrayDynamicIndexing          bool `json:"shaderInputAttachmentArrayDynamicIndexing"`
+	ShaderUniformTexelBufferArrayDynamicIndexing       bool `json:"shaderUniformTexelBufferArrayDynamicIndexing"`
+	ShaderStorageTexelBufferArrayDynamicIndexing       bool `json:"shaderStorageTexelBufferArrayDynamicIndexing"`
+	ShaderUniformBufferArrayNonUniformIndexing         bool `json:"shaderUniformBufferArrayNonUniformIndexing"`
+	ShaderSampledImageArrayNonUniformIndexing          bool `json:"shaderSampledImageArrayNonUniformIndexing"`
+	ShaderStorageBufferArrayNonUniformIndexing         bool `json:"shaderStorageBufferArrayNonUniformIndexing"`
+	ShaderStorageImageArrayNonUniformIndexing          bool `json:"shaderStorageImageArrayNonUniformIndexing"`
+	ShaderInputAttachmentArrayNonUniformIndexing       bool `json:"shaderInputAttachmentArrayNonUniformIndexing"`
+	ShaderUniformTexelBufferArrayNonUniformIndexing    bool `json:"shaderUniformTexelBufferArrayNonUniformIndexing"`
+	ShaderStorageTexelBufferArrayNonUniformIndexing    bool `json:"shaderStorageTexelBufferArrayNonUniformIndexing"`
+	DescriptorBindingUniformBufferUpdateAfterBind      bool `json:"descriptorBindingUniformBufferUpdateAfterBind"`
+	DescriptorBindingSampledImageUpdateAfterBind       bool `json:"descriptorBindingSampledImageUpdateAfterBind"`
+	DescriptorBindingStorageImageUpdateAfterBind       bool `json:"descriptorBindingStorageImageUpdateAfterBind"`
+	DescriptorBindingStorageBufferUpdateAfterBind      bool `json:"descriptorBindingStorageBufferUpdateAfterBind"`
+	DescriptorBindingUniformTexelBufferUpdateAfterBind bool `json:"descriptorBindingUniformTexelBufferUpdateAfterBind"`
+	DescriptorBindingStorageTexelBufferUpdateAfterBind bool `json:"descriptorBindingStorageTexelBufferUpdateAfterBind"`
+	DescriptorBindingUpdateUnusedWhilePending          bool `json:"descriptorBindingUpdateUnusedWhilePending"`
+	DescriptorBindingPartiallyBound                    bool `json:"descriptorBindingPartiallyBound"`
+	DescriptorBindingVariableDescriptorCount           bool `json:"descriptorBindingVariableDescriptorCount"`
+	RuntimeDescriptorArray                             bool `json:"runtimeDescriptorArray"`
+	SamplerFilterMinmax                                bool `json:"samplerFilterMinmax"`
+	ScalarBlockLayout                                  bool `json:"scalarBlockLayout"`
+	ImagelessFramebuffer                               bool `json:"imagelessFramebuffer"`
+	UniformBufferStandardLayout                        bool `json:"uniformBufferStandardLayout"`
+	ShaderSubgroupExtendedTypes                        bool `json:"shaderSubgroupExtendedTypes"`
+	SeparateDepthStencilLayouts                        bool `json:"separateDepthStencilLayouts"`
+	HostQueryReset                                     bool `json:"hostQueryReset"`
+	TimelineSemaphore                                  bool `json:"timelineSemaphore"`
+	BufferDeviceAddress                                bool `json:"bufferDeviceAddress"`
+	BufferDeviceAddressCaptureReplay                   bool `json:"bufferDeviceAddressCaptureReplay"`
+	BufferDeviceAddressMultiDevice                     bool `json:"bufferDeviceAddressMultiDevice"`
+	VulkanMemoryModel                                  bool `json:"vulkanMemoryModel"`
+	VulkanMemoryModelDeviceScope                       bool `json:"vulkanMemoryModelDeviceScope"`
+	VulkanMemoryModelAvailabilityVisibilityChains      bool `json:"vulkanMemoryModelAvailabilityVisibilityChains"`
+	ShaderOutputViewportIndex                          bool `json:"shaderOutputViewportIndex"`
+	ShaderOutputLayer                                  bool `json:"shaderOutputLayer"`
+	SubgroupBroadcastDynamicID                         bool `json:"subgroupBroadcastDynamicId"`
+}
+
+// Vulkan13Features mirrors VkPhysicalDeviceVulkan13Features.
+type Vulkan13Features struct {
+	RobustImageAccess                                  bool `json:"robustImageAccess"`
+	InlineUniformBlock                                 bool `json:"inlineUniformBlock"`
+	DescriptorBindingInlineUniformBlockUpdateAfterBind bool `json:"descriptorBindingInlineUniformBlockUpdateAfterBind"`
+	PipelineCreationCacheControl                       bool `json:"pipelineCreationCacheControl"`
+	PrivateData                                        bool `json:"privateData"`
+	ShaderDemoteToHelperInvocation                     bool `json:"shaderDemoteToHelperInvocation"`
+	ShaderTerminateInvocation                          bool `json:"shaderTerminateInvocation"`
+	SubgroupSizeControl                                bool `json:"subgroupSizeControl"`
+	ComputeFullSubgroups                               bool `json:"computeFullSubgroups"`
+	Synchronization2                                   bool `json:"synchronization2"`
+	TextureCompressionASTCHDR                          bool `json:"textureCompressionASTC_HDR"`
+	ShaderZeroInitializeWorkgroupMemory                bool `json:"shaderZeroInitializeWorkgroupMemory"`
+	DynamicRendering                                   bool `json:"dynamicRendering"`
+	ShaderIntegerDotProduct                            bool `json:"shaderIntegerDotProduct"`
+	Maintenance4                                       bool `json:"maintenance4"`
+}
+
+type SubgroupProperties struct {
+	SubgroupSize              uint32   `json:"subgroupSize"`
+	SupportedStages           []string `json:"supportedStages"`
+	SupportedOperations       []string `json:"supportedOperations"`
+	QuadOperationsInAllStages bool     `json:"quadOperationsInAllStages"`
+}
+
+type DriverProperties struct {
+	DriverID           string `json:"driverID"`
+	DriverName         string `json:"driverName"`
+	DriverInfo         string `json:"driverInfo"`
+	ConformanceVersion string `json:"conformanceVersion"`
+}
+
+// DescriptorIndexing holds the descriptor indexing support, which is core
+// since Vulkan 1.2 and provided by VK_EXT_descriptor_indexing before.
+type DescriptorIndexing struct {
+	Features   DescriptorIndexingFeatures   `json:"features"`
+	Properties DescriptorIndexingProperties `json:"properties"`
+}
+
+// DescriptorIndexingFeatures mirrors VkPhysicalDeviceDescriptorIndexingFeatures.
+type DescriptorIndexingFeatures struct {
+	ShaderInputAttachmentArrayDynamicIndexing          bool `json:"shaderInputAttachmentArrayDynamicIndexing"`
+	ShaderUniformTexelBufferArrayDynamicIndexing       bool `json:"shaderUniformTexelBufferArrayDynamicIndexing"`
+	ShaderStorageTexelBufferArrayDynamicIndexing       bool `json:"shaderStorageTexelBufferArrayDynamicIndexing"`
+	ShaderUniformBufferArrayNonUniformIndexing         bool `json:"shaderUniformBufferArrayNonUniformIndexing"`
+	ShaderSampledImageArrayNonUniformIndexing          bool `json:"shaderSampledImageArrayNonUniformIndexing"`
+	ShaderStorageBufferArrayNonUniformIndexing         bool `json:"shaderStorageBufferArrayNonUniformIndexing"`
+	ShaderStorageImageArrayNonUniformIndexing          bool `json:"shaderStorageImageArrayNonUniformIndexing"`
+	ShaderInputAttachmentArrayNonUniformIndexing       bool `json:"shaderInputAttachmentArrayNonUniformIndexing"`
+	ShaderUniformTexelBufferArrayNonUniformIndexing    bool `json:"shaderUniformTexelBufferArrayNonUniformIndexing"`
+	ShaderStorageTexelBufferArrayNonUniformIndexing    bool `json:"shaderStorageTexelBufferArrayNonUniformIndexing"`
+	DescriptorBindingUniformBufferUpdateAfterBind      bool `json:"descriptorBindingUniformBufferUpdateAfterBind"`
+	DescriptorBindingSampledImageUpdateAfterBind       bool `json:"descriptorBindingSampledImageUpdateAfterBind"`
+	DescriptorBindingStorageImageUpdateAfterBind       bool `json:"descriptorBindingStorageImageUpdateAfterBind"`
+	DescriptorBindingStorageBufferUpdateAfterBind      bool `json:"descriptorBindingStorageBufferUpdateAfterBind"`
+	DescriptorBindingUniformTexelBufferUpdateAfterBind bool `json:"descriptorBindingUniformTexelBufferUpdateAfterBind"`
+	DescriptorBindingStorageTexelBufferUpdateAfterBind bool `json:"descriptorBindingStorageTexelBufferUpdateAfterBind"`
+	DescriptorBindingUpdateUnusedWhilePending          bool `json:"descriptorBindingUpdateUnusedWhilePending"`
+	DescriptorBindingPartiallyBound                    bool `json:"descriptorBindingPartiallyBound"`
+	DescriptorBindingVariableDescriptorCount           bool `json:"descriptorBindingVariableDescriptorCount"`
+	RuntimeDescriptorArray                             bool `json:"runtimeDescriptorArray"`
+}
+
+// DescriptorIndexingProperties mirrors VkPhysicalDeviceDescriptorIndexingProperties.
+type DescriptorIndexingProperties struct {
+	MaxUpdateAfterBindDescriptorsInAllPools              uint32 `json:"maxUpdateAfterBindDescriptorsInAllPools"`
+	ShaderUniformBufferArrayNonUniformIndexingNative     bool   `json:"shaderUniformBufferArrayNonUniformIndexingNative"`
+	ShaderSampledImageArrayNonUniformIndexingNative      bool   `json:"shaderSampledImageArrayNonUniformIndexingNative"`
+	ShaderStorageBufferArrayNonUniformIndexingNative     bool   `json:"shaderStorageBufferArrayNonUniformIndexingNative"`
+	ShaderStorageImageArrayNonUniformIndexingNative      bool   `json:"shaderStorageImageArrayNonUniformIndexingNative"`
+	ShaderInputAttachmentArrayNonUniformIndexingNative   bool   `json:"shaderInputAttachmentArrayNonUniformIndexingNative"`
+	RobustBufferAccessUpdateAfterBind                    bool   `json:"robustBufferAccessUpdateAfterBind"`
+	QuadDivergentImplicitLod                             bool   `json:"quadDivergentImplicitLod"`
+	MaxPerStageDescriptorUpdateAfterBindSamplers         uint32 `json:"maxPerStageDescriptorUpdateAfterBindSamplers"`
+	MaxPerStageDescriptorUpdateAfterBindUniformBuffers   uint32 `json:"maxPerStageDescriptorUpdateAfterBindUniformBuffers"`
+	MaxPerStageDescriptorUpdateAfterBindStorageBuffers   uint32 `json:"maxPerStageDescriptorUpdateAfterBindStorageBuffers"`
+	MaxPerStageDescriptorUpdateAfterBindSampledImages    uint32 `json:"maxPerStageDescriptorUpdateAfterBindSampledImages"`
+	MaxPerStageDescriptorUpdateAfterBindStorageImages    uint32 `json:"maxPerStageDescriptorUpdateAfterBindStorageImages"`
+	MaxPerStageDescriptorUpdateAfterBindInputAttachments uint32 `json:"maxPerStageDescriptorUpdateAfterBindInputAttachments"`
+	MaxPerStageUpdateAfterBindResources                  uint32 `json:"maxPerStageUpdateAfterBindResources"`
+	MaxDescriptorSetUpdateAfterBindSamplers              uint32 `json:"maxDescriptorSetUpdateAfterBindSamplers"`
+	MaxDescriptorSetUpdateAfterBindUniformBuffers        uint32 `json:"maxDescriptorSetUpdateAfterBindUniformBuffers"`
+	MaxDescriptorSetUpdateAfterBindUniformBuffersDynamic uint32 `json:"maxDescriptorSetUpdateAfterBindUniformBuffersDynamic"`
+	MaxDescriptorSetUpdateAfterBindStorageBuffers        uint32 `json:"maxDescriptorSetUpdateAfterBindStorageBuffers"`
+	MaxDescriptorSetUpdateAfterBindStorageBuffersDynamic uint32 `json:"maxDescriptorSetUpdateAfterBindStorageBuffersDynamic"`
+	MaxDescriptorSetUpdateAfterBindSampledImages         uint32 `json:"maxDescriptorSetUpdateAfterBindSampledImages"`
+	MaxDescriptorSetUpdateAfterBindStorageImages         uint32 `json:"maxDescriptorSetUpdateAfterBindStorageImages"`
+	MaxDescriptorSetUpdateAfterBindInputAttachments      uint32 `json:"maxDescriptorSetUpdateAfterBindInputAttachments"`
+}
+
+// chain is a pNext chain of structures allocated in C memory.
+type chain []*C.chainStruct
+
+func (c *chain) add(sType uint32) *C.chainStruct {
+	s := (*C.chainStruct)(C.calloc(1, C.sizeof_chainStruct))
+	s.sType = C.uint32_t(sType)
+	if n := len(*c); n > 0 {
+		(*c)[n-1].pNext = unsafe.Pointer(s)
+	}
+	*c = append(*c, s)
+	return s
+}
+
+func (c chain) free() {
+	for _, s := range c {
+		C.free(unsafe.Pointer(s))
+	}
+}
+
+func words(s *C.chainStruct) []uint32 {
+	return (*[256]uint32)(unsafe.Pointer(&s.data))[:]
+}
+
+// fillFields sets the bool and uint32 fields of the struct from
+// the 32-bit members of the Vulkan structure, in declaration order.
+func fillFields(v interface{}, values []uint32) {
+	rv := reflect.ValueOf(v).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		switch f := rv.Field(i); f.Kind() {
+		case reflect.Bool:
+			f.SetBool(values[i] != 0)
+		case reflect.Uint32:
+			f.SetUint(uint64(values[i]))
+		}
+	}
+}
+
+// copyFields sets the fields of dst from the fields of src with the same name.
+func copyFields(dst, src interface{}) {
+	rd, rs := reflect.ValueOf(dst).Elem(), reflect.ValueOf(src).Elem()
+	for i := 0; i < rd.NumField(); i++ {
+		if f := rs.FieldByName(rd.Type().Field(i).Name); f.IsValid() {
+			rd.Field(i).Set(f)
+		}
+	}
+}
+
+// physicalDeviceFunc resolves a vkGetPhysicalDevice*2 entry point, using the
+// KHR alias when the instance is 1.0 with VK_KHR_get_physical_device_properties2.
+func (v *VulkanDeviceInfo) physicalDeviceFunc(name string) C.vkVoidFunction {
+	switch {
+	case v.apiVersion >= vk.MakeVersion(1, 1, 0):
+	case v.properties2KHR:
+		name += "KHR"
+	default:
+		return nil
+	}
+	return C.vkVoidFunction(vkproc.InstanceProc(v.instance, name))
+}
+
+// addFeatures2 fills the Vulkan 1.1+ features and properties of the device report
+// using vkGetPhysicalDeviceFeatures2 and vkGetPhysicalDeviceProperties2,
+// nothing is added when neither Vulkan 1.1 nor the KHR extension is available.
+func (v *VulkanDeviceInfo) addFeatures2(d *DeviceReport, gpu vk.PhysicalDevice, apiVersion uint32) {
+	if apiVersion > v.apiVersion {
+		apiVersion = v.apiVersion
+	}
+	hasExtension := func(name string) bool {
+		return hasName(extensionNames(d.Extensions), name)
+	}
+	vulkan12 := apiVersion >= vk.MakeVersion(1, 2, 0)
+
+	if fn := v.physicalDeviceFunc("vkGetPhysicalDeviceFeatures2"); fn != nil {
+		var features chain
+		features.add(uint32(vk.StructureTypePhysicalDeviceFeatures2))
+		var v11, v12, v13, indexing *C.chainStruct
+		var v11Structs []*C.chainStruct
+		if vulkan12 {
+			v11 = features.add(structureTypePhysicalDeviceVulkan11Features)
+			v12 = features.add(structureTypePhysicalDeviceVulkan12Features)
+		} else {
+			if apiVersion >= vk.MakeVersion(1, 1, 0) {
+				for _, s := range vulkan11FeatureStructs {
+					v11Structs = append(v11Structs, features.add(uint32(s.sType)))
+				}
+			}
+			if hasExtension("VK_EXT_descriptor_indexing") {
+				indexing = features.add(uint32(vk.StructureTypePhysicalDeviceDescriptorIndexingFeatures))
+			}
+		}
+		if apiVersion >= vk.MakeVersion(1, 3, 0) {
+			v13 = features.add(structureTypePhysicalDeviceVulkan13Features)
+		}
+		C.callPhysicalDeviceFunc(fn, unsafe.Pointer(gpu), features[0])
+
+		d.DescriptorIndexing = &DescriptorIndexing{}
+		if v11 != nil {
+			d.Vulkan11Features = &Vulkan11Features{}
+			fillFields(d.Vulkan11Features, words(v11))
+		} else if len(v11Structs) > 0 {
+			var values []uint32
+			for i, s := range v11Structs {
+				values = append(values, words(s)[:vulkan11FeatureStructs[i].members]...)
+			}
+			d.Vulkan11Features = &Vulkan11Features{}
+			fillFields(d.Vulkan11Features, values)
+		}
+		if v12 != nil {
+			d.Vulkan12Features = &Vulkan12Features{}
+			fillFields(d.Vulkan12Features, words(v12))
+			copyFields(&d.DescriptorIndexing.Features, d.Vulkan12Features)
+		}
+		if v13 != nil {
+			d.Vulkan13Features = &Vulkan13Features{}
+			fillFields(d.Vulkan13Features, words(v13))
+		}
+		if indexing != nil {
+			fillFields(&d.DescriptorIndexing.Features, words(indexing))
+		}
+		if v12 == nil && indexing == nil {
+			d.DescriptorIndexing = nil
+		}
+		features.free()
+	}
+
+	if fn := v.physicalDeviceFunc("vkGetPhysicalDeviceProperties2"); fn != nil {
+		var properties chain
+		properties.add(uint32(vk.StructureTypePhysicalDeviceProperties2))
+		var subgroup, driver, indexing *C.chainStruct
+		if apiVersion >= vk.MakeVersion(1, 1, 0) {
+			subgroup = properties.add(uint32(vk.StructureTypePhysicalDeviceSubgroupProperties))
+		}
+		if vulkan12 || hasExtension("VK_KHR_driver_properties") {
+			driver = properties.add(uint32(vk.StructureTypePhysicalDeviceDriverProperties))
+		}
+		if d.DescriptorIndexing != nil {
+			indexing = properties.add(uint32(vk.StructureTypePhysicalDeviceDescriptorIndexingProperties))
+		}
+		C.callPhysicalDeviceFunc(fn, unsafe.Pointer(gpu), properties[0])
+
+		if subgroup != nil {
+			w := words(subgroup)
+			d.Subgroup = &SubgroupProperties{
+				SubgroupSize:              w[0],
+				SupportedStages:           flagNames(w[1], shaderStageFlagNames),
+				SupportedOperations:       flagNames(w[2], subgroupFeatureFlagNames),
+				QuadOperationsInAllStages: w[3] != 0,
+			}
+		}
+		if driver != nil {
+			b := (*[4 + 2*vk.MaxDriverNameSize + 4]byte)(unsafe.Pointer(&driver.data))
+			info := b[4+vk.MaxDriverNameSize:]
+			version := info[vk.MaxDriverInfoSize:]
+			d.Driver = &DriverProperties{
+				DriverID:           driverIDName(vk.DriverId(words(driver)[0])),
+				DriverName:         vk.ToString(b[4 : 4+vk.MaxDriverNameSize]),
+				DriverInfo:         vk.ToString(info[:vk.MaxDriverInfoSize]),
+				ConformanceVersion: fmt.Sprintf("%d.%d.%d.%d", version[0], version[1], version[2], version[3]),
+			}
+		}
+		if indexing != nil {
+			fillFields(&d.DescriptorIndexing.Properties, words(indexing))
+		}
+		properties.free()
+	}
+}
+
+var shaderStageFlagNames = []flagName{
+	{uint32(vk.ShaderStageVertexBit), "Vertex"},
+	{uint32(vk.ShaderStageTessellationControlBit), "TessellationControl"},
+	{uint32(vk.ShaderStageTessellationEvaluationBit), "TessellationEvaluation"},
+	{uint32(vk.ShaderStageGeometryBit), "Geometry"},
+	{uint32(vk.ShaderStageFragmentBit), "Fragment"},
+	{uint32(vk.ShaderStageComputeBit), "Compute"},
+}
+
+var subgroupFeatureFlagNames = []flagName{
+	{uint32(vk.SubgroupFeatureBasicBit), "Basic"},
+	{uint32(vk.SubgroupFeatureVoteBit), "Vote"},
+	{uint32(vk.SubgroupFeatureArithmeticBit), "Arithmetic"},
+	{uint32(vk.SubgroupFeatureBallotBit), "Ballot"},
+	{uint32(vk.SubgroupFeatureShuffleBit), "Shuffle"},
+	{uint32(vk.SubgroupFeatureShuffleRelativeBit), "ShuffleRelative"},
+	{uint32(vk.SubgroupFeatureClusteredBit), "Clustered"},
+	{uint32(vk.SubgroupFeatureQuadBit), "Quad"},
+}
+
+// The driver IDs registered after VK_DRIVER_ID_ARM_PROPRIETARY,
+// the vulkan package doesn't declare them.
+const (
+	driverIDGoogleSwiftShader vk.DriverId = iota + 10
+	driverIDGgpProprietary
+	driverIDBroadcomProprietary
+	driverIDMesaLlvmpipe
+	driverIDMoltenVK
+	driverIDCoreaviProprietary
+	driverIDJuiceProprietary
+	driverIDVerisiliconProprietary
+	driverIDMesaTurnip
+	driverIDMesaV3dv
+	driverIDMesaPanvk
+	driverIDSamsungProprietary
+	driverIDMesaVenus
+	driverIDMesaDozen
+	driverIDMesaNvk
+	driverIDImaginationOpenSourceMesa
+	driverIDMesaAgxv
+)
+
+var driverIDNames = map[vk.DriverId]string{
+	vk.DriverIdAmdProprietary:          "AMD proprietary",
+	vk.DriverIdAmdOpenSource:           "AMD open-source",
+	vk.DriverIdMesaRadv:                "Mesa RADV",
+	vk.DriverIdNvidiaProprietary:       "NVIDIA proprietary",
+	vk.DriverIdIntelProprietaryWindows: "Intel proprietary Windows",
+	vk.DriverIdIntelOpenSourceMesa:     "Intel open-source Mesa",
+	vk.DriverIdImaginationProprietary:  "Imagination proprietary",
+	vk.DriverIdQualcommProprietary:     "Qualcomm proprietary",
+	vk.DriverIdArmProprietary:          "Arm proprietary",
+	driverIDGoogleSwiftShader:          "Google SwiftShader",
+	driverIDGgpProprietary:             "GGP proprietary",
+	driverIDBroadcomProprietary:        "Broadcom proprietary",
+	driverIDMesaLlvmpipe:               "Mesa LLVMpipe",
+	driverIDMoltenVK:                   "MoltenVK",
+	driverIDCoreaviProprietary:         "Core AVIONICS & FPGA proprietary",
+	driverIDJuiceProprietary:           "Juice proprietary",
+	driverIDVerisiliconProprietary:     "Verisilicon proprietary",
+	driverIDMesaTurnip:                 "Mesa Turnip",
+	driverIDMesaV3dv:                   "Mesa V3DV",
+	driverIDMesaPanvk:                  "Mesa PanVK",
+	driverIDSamsungProprietary:         "Samsung proprietary",
+	driverIDMesaVenus:                  "Mesa Venus",
+	driverIDMesaDozen:                  "Mesa Dozen",
+	driverIDMesaNvk:                    "Mesa NVK",
+	driverIDImaginationOpenSourceMesa:  "Imagination open-source Mesa",
+	driverIDMesaAgxv:                   "Mesa AGXV",
+}
+
+func driverIDName(id vk.DriverId) string {
+	if name, ok := driverIDNames[id]; ok {
+		return name
+	}
+	return fmt.Sprintf("unknown driver (%d)", id)
+}
@@ -0,0 +1,32 @@
+package vulkaninfo
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestVulkan11FeatureStructs makes sure the individual structures of a Vulkan 1.1 device
+// fill every field of Vulkan11Features, and nothing past them.
+func TestVulkan11FeatureStructs(t *testing.T) {
+	var members int
+	for _, s := range vulkan11FeatureStructs {
+		members += s.members
+	}
+	if fields := reflect.TypeOf(Vulkan11Features{}).NumField(); members != fields {
+		t.Errorf("vulkan11FeatureStructs have %d members, Vulkan11Features has %d fields", members, fields)
+	}
+
+	// multiview and shader draw parameters only, as the structures are concatenated
+	values := []uint32{0, 0, 0, 0, 1, 0, 0, 0, 0, 0, 0, 1}
+	var f Vulkan11Features
+	fillFields(&f, values)
+	if want := (Vulkan11Features{Multiview: true, ShaderDrawParameters: true}); f != want {
+		t.Errorf("got %+v, want %+v", f, want)
+	}
+}
+
+func TestDriverIDName(t *testing.T) {
+	if got := driverIDName(driverIDMesaAgxv); got != "Mesa AGXV" || driverIDMesaAgxv != 26 {
+		t.Errorf("driverIDName(%d) = %q, want Mesa AGXV for 26", driverIDMesaAgxv, got)
+	}
+}
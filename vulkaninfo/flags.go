@@ -0,0 +1,104 @@
+package vulkaninfo
+
+import (
+	"fmt"
+	"strings"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+type flagName struct {
+	bit  uint32
+	name string
+}
+
+// flagNames decodes a bitmask into the names of its bits,
+// unknown bits are kept as hex values so nothing gets lost.
+func flagNames(flags uint32, names []flagName) []string {
+	var out []string
+	for _, f := range names {
+		if flags&f.bit != 0 {
+			out = append(out, f.name)
+			flags &^= f.bit
+		}
+	}
+	for bit := uint32(1); flags != 0; bit <<= 1 {
+		if flags&bit != 0 {
+			out = append(out, fmt.Sprintf("0x%x", bit))
+			flags &^= bit
+		}
+	}
+	return out
+}
+
+func joinFlags(names []string) string {
+	if len(names) == 0 {
+		return "None"
+	}
+	return strings.Join(names, "|")
+}
+
+var queueFlagNames = []flagName{
+	{uint32(vk.QueueGraphicsBit), "Graphics"},
+	{uint32(vk.QueueComputeBit), "Compute"},
+	{uint32(vk.QueueTransferBit), "Transfer"},
+	{uint32(vk.QueueSparseBindingBit), "SparseBinding"},
+	{uint32(vk.QueueProtectedBit), "Protected"},
+}
+
+var memoryPropertyFlagNames = []flagName{
+	{uint32(vk.MemoryPropertyDeviceLocalBit), "DeviceLocal"},
+	{uint32(vk.MemoryPropertyHostVisibleBit), "HostVisible"},
+	{uint32(vk.MemoryPropertyHostCoherentBit), "HostCoherent"},
+	{uint32(vk.MemoryPropertyHostCachedBit), "HostCached"},
+	{uint32(vk.MemoryPropertyLazilyAllocatedBit), "LazilyAllocated"},
+	{uint32(vk.MemoryPropertyProtectedBit), "Protected"},
+}
+
+var memoryHeapFlagNames = []flagName{
+	{uint32(vk.MemoryHeapDeviceLocalBit), "DeviceLocal"},
+	{uint32(vk.MemoryHeapMultiInstanceBit), "MultiInstance"},
+}
+
+var imageUsageFlagNames = []flagName{
+	{uint32(vk.ImageUsageTransferSrcBit), "TransferSrc"},
+	{uint32(vk.ImageUsageTransferDstBit), "TransferDst"},
+	{uint32(vk.ImageUsageSampledBit), "Sampled"},
+	{uint32(vk.ImageUsageStorageBit), "Storage"},
+	{uint32(vk.ImageUsageColorAttachmentBit), "ColorAttachment"},
+	{uint32(vk.ImageUsageDepthStencilAttachmentBit), "DepthStencilAttachment"},
+	{uint32(vk.ImageUsageTransientAttachmentBit), "TransientAttachment"},
+	{uint32(vk.ImageUsageInputAttachmentBit), "InputAttachment"},
+}
+
+var surfaceTransformFlagNames = []flagName{
+	{uint32(vk.SurfaceTransformIdentityBit), "Identity"},
+	{uint32(vk.SurfaceTransformRotate90Bit), "Rotate90"},
+	{uint32(vk.SurfaceTransformRotate180Bit), "Rotate180"},
+	{uint32(vk.SurfaceTransformRotate270Bit), "Rotate270"},
+	{uint32(vk.SurfaceTransformHorizontalMirrorBit), "HorizontalMirror"},
+	{uint32(vk.SurfaceTransformHorizontalMirrorRotate90Bit), "HorizontalMirrorRotate90"},
+	{uint32(vk.SurfaceTransformHorizontalMirrorRotate180Bit), "HorizontalMirrorRotate180"},
+	{uint32(vk.SurfaceTransformHorizontalMirrorRotate270Bit), "HorizontalMirrorRotate270"},
+	{uint32(vk.SurfaceTransformInheritBit), "Inherit"},
+}
+
+var compositeAlphaFlagNames = []flagName{
+	{uint32(vk.CompositeAlphaOpaqueBit), "Opaque"},
+	{uint32(vk.CompositeAlphaPreMultipliedBit), "PreMultiplied"},
+	{uint32(vk.CompositeAlphaPostMultipliedBit), "PostMultiplied"},
+	{uint32(vk.CompositeAlphaInheritBit), "Inherit"},
+}
+
+func formatSize(size uint64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := uint64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
@@ -0,0 +1,458 @@
+package vulkaninfo
+
+import (
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// coreFormats lists the formats of the Vulkan 1.0 core in enum order, except vk.FormatUndefined.
+var coreFormats = []vk.Format{
+	vk.FormatR4g4UnormPack8,
+	vk.FormatR4g4b4a4UnormPack16,
+	vk.FormatB4g4r4a4UnormPack16,
+	vk.FormatR5g6b5UnormPack16,
+	vk.FormatB5g6r5UnormPack16,
+	vk.FormatR5g5b5a1UnormPack16,
+	vk.FormatB5g5r5a1UnormPack16,
+	vk.FormatA1r5g5b5UnormPack16,
+	vk.FormatR8Unorm,
+	vk.FormatR8Snorm,
+	vk.FormatR8Uscaled,
+	vk.FormatR8Sscaled,
+	vk.FormatR8Uint,
+	vk.FormatR8Sint,
+	vk.FormatR8Srgb,
+	vk.FormatR8g8Unorm,
+	vk.FormatR8g8Snorm,
+	vk.FormatR8g8Uscaled,
+	vk.FormatR8g8Sscaled,
+	vk.FormatR8g8Uint,
+	vk.FormatR8g8Sint,
+	vk.FormatR8g8Srgb,
+	vk.FormatR8g8b8Unorm,
+	vk.FormatR8g8b8Snorm,
+	vk.FormatR8g8b8Uscaled,
+	vk.FormatR8g8b8Sscaled,
+	vk.FormatR8g8b8Uint,
+	vk.FormatR8g8b8Sint,
+	vk.FormatR8g8b8Srgb,
+	vk.FormatB8g8r8Unorm,
+	vk.FormatB8g8r8Snorm,
+	vk.FormatB8g8r8Uscaled,
+	vk.FormatB8g8r8Sscaled,
+	vk.FormatB8g8r8Uint,
+	vk.FormatB8g8r8Sint,
+	vk.FormatB8g8r8Srgb,
+	vk.FormatR8g8b8a8Unorm,
+	vk.FormatR8g8b8a8Snorm,
+	vk.FormatR8g8b8a8Uscaled,
+	vk.FormatR8g8b8a8Sscaled,
+	vk.FormatR8g8b8a8Uint,
+	vk.FormatR8g8b8a8Sint,
+	vk.FormatR8g8b8a8Srgb,
+	vk.FormatB8g8r8a8Unorm,
+	vk.FormatB8g8r8a8Snorm,
+	vk.FormatB8g8r8a8Uscaled,
+	vk.FormatB8g8r8a8Sscaled,
+	vk.FormatB8g8r8a8Uint,
+	vk.FormatB8g8r8a8Sint,
+	vk.FormatB8g8r8a8Srgb,
+	vk.FormatA8b8g8r8UnormPack32,
+	vk.FormatA8b8g8r8SnormPack32,
+	vk.FormatA8b8g8r8UscaledPack32,
+	vk.FormatA8b8g8r8SscaledPack32,
+	vk.FormatA8b8g8r8UintPack32,
+	vk.FormatA8b8g8r8SintPack32,
+	vk.FormatA8b8g8r8SrgbPack32,
+	vk.FormatA2r10g10b10UnormPack32,
+	vk.FormatA2r10g10b10SnormPack32,
+	vk.FormatA2r10g10b10UscaledPack32,
+	vk.FormatA2r10g10b10SscaledPack32,
+	vk.FormatA2r10g10b10UintPack32,
+	vk.FormatA2r10g10b10SintPack32,
+	vk.FormatA2b10g10r10UnormPack32,
+	vk.FormatA2b10g10r10SnormPack32,
+	vk.FormatA2b10g10r10UscaledPack32,
+	vk.FormatA2b10g10r10SscaledPack32,
+	vk.FormatA2b10g10r10UintPack32,
+	vk.FormatA2b10g10r10SintPack32,
+	vk.FormatR16Unorm,
+	vk.FormatR16Snorm,
+	vk.FormatR16Uscaled,
+	vk.FormatR16Sscaled,
+	vk.FormatR16Uint,
+	vk.FormatR16Sint,
+	vk.FormatR16Sfloat,
+	vk.FormatR16g16Unorm,
+	vk.FormatR16g16Snorm,
+	vk.FormatR16g16Uscaled,
+	vk.FormatR16g16Sscaled,
+	vk.FormatR16g16Uint,
+	vk.FormatR16g16Sint,
+	vk.FormatR16g16Sfloat,
+	vk.FormatR16g16b16Unorm,
+	vk.FormatR16g16b16Snorm,
+	vk.FormatR16g16b16Uscaled,
+	vk.FormatR16g16b16Sscaled,
+	vk.FormatR16g16b16Uint,
+	vk.FormatR16g16b16Sint,
+	vk.FormatR16g16b16Sfloat,
+	vk.FormatR16g16b16a16Unorm,
+	vk.FormatR16g16b16a16Snorm,
+	vk.FormatR16g16b16a16Uscaled,
+	vk.FormatR16g16b16a16Sscaled,
+	vk.FormatR16g16b16a16Uint,
+	vk.FormatR16g16b16a16Sint,
+	vk.FormatR16g16b16a16Sfloat,
+	vk.FormatR32Uint,
+	vk.FormatR32Sint,
+	vk.FormatR32Sfloat,
+	vk.FormatR32g32Uint,
+	vk.FormatR32g32Sint,
+	vk.FormatR32g32Sfloat,
+	vk.FormatR32g32b32Uint,
+	vk.FormatR32g32b32Sint,
+	vk.FormatR32g32b32Sfloat,
+	vk.FormatR32g32b32a32Uint,
+	vk.FormatR32g32b32a32Sint,
+	vk.FormatR32g32b32a32Sfloat,
+	vk.FormatR64Uint,
+	vk.FormatR64Sint,
+	vk.FormatR64Sfloat,
+	vk.FormatR64g64Uint,
+	vk.FormatR64g64Sint,
+	vk.FormatR64g64Sfloat,
+	vk.FormatR64g64b64Uint,
+	vk.FormatR64g64b64Sint,
+	vk.FormatR64g64b64Sfloat,
+	vk.FormatR64g64b64a64Uint,
+	vk.FormatR64g64b64a64Sint,
+	vk.FormatR64g64b64a64Sfloat,
+	vk.FormatB10g11r11UfloatPack32,
+	vk.FormatE5b9g9r9UfloatPack32,
+	vk.FormatD16Unorm,
+	vk.FormatX8D24UnormPack32,
+	vk.FormatD32Sfloat,
+	vk.FormatS8Uint,
+	vk.FormatD16UnormS8Uint,
+	vk.FormatD24UnormS8Uint,
+	vk.FormatD32SfloatS8Uint,
+	vk.FormatBc1RgbUnormBlock,
+	vk.FormatBc1RgbSrgbBlock,
+	vk.FormatBc1RgbaUnormBlock,
+	vk.FormatBc1RgbaSrgbBlock,
+	vk.FormatBc2UnormBlock,
+	vk.FormatBc2SrgbBlock,
+	vk.FormatBc3UnormBlock,
+	vk.FormatBc3SrgbBlock,
+	vk.FormatBc4UnormBlock,
+	vk.FormatBc4SnormBlock,
+	vk.FormatBc5UnormBlock,
+	vk.FormatBc5SnormBlock,
+	vk.FormatBc6hUfloatBlock,
+	vk.FormatBc6hSfloatBlock,
+	vk.FormatBc7UnormBlock,
+	vk.FormatBc7SrgbBlock,
+	vk.FormatEtc2R8g8b8UnormBlock,
+	vk.FormatEtc2R8g8b8SrgbBlock,
+	vk.FormatEtc2R8g8b8a1UnormBlock,
+	vk.FormatEtc2R8g8b8a1SrgbBlock,
+	vk.FormatEtc2R8g8b8a8UnormBlock,
+	vk.FormatEtc2R8g8b8a8SrgbBlock,
+	vk.FormatEacR11UnormBlock,
+	vk.FormatEacR11SnormBlock,
+	vk.FormatEacR11g11UnormBlock,
+	vk.FormatEacR11g11SnormBlock,
+	vk.FormatAstc4x4UnormBlock,
+	vk.FormatAstc4x4SrgbBlock,
+	vk.FormatAstc5x4UnormBlock,
+	vk.FormatAstc5x4SrgbBlock,
+	vk.FormatAstc5x5UnormBlock,
+	vk.FormatAstc5x5SrgbBlock,
+	vk.FormatAstc6x5UnormBlock,
+	vk.FormatAstc6x5SrgbBlock,
+	vk.FormatAstc6x6UnormBlock,
+	vk.FormatAstc6x6SrgbBlock,
+	vk.FormatAstc8x5UnormBlock,
+	vk.FormatAstc8x5SrgbBlock,
+	vk.FormatAstc8x6UnormBlock,
+	vk.FormatAstc8x6SrgbBlock,
+	vk.FormatAstc8x8UnormBlock,
+	vk.FormatAstc8x8SrgbBlock,
+	vk.FormatAstc10x5UnormBlock,
+	vk.FormatAstc10x5SrgbBlock,
+	vk.FormatAstc10x6UnormBlock,
+	vk.FormatAstc10x6SrgbBlock,
+	vk.FormatAstc10x8UnormBlock,
+	vk.FormatAstc10x8SrgbBlock,
+	vk.FormatAstc10x10UnormBlock,
+	vk.FormatAstc10x10SrgbBlock,
+	vk.FormatAstc12x10UnormBlock,
+	vk.FormatAstc12x10SrgbBlock,
+	vk.FormatAstc12x12UnormBlock,
+	vk.FormatAstc12x12SrgbBlock,
+}
+
+var formatNames = map[vk.Format]string{
+	vk.FormatUndefined:                "Undefined",
+	vk.FormatR4g4UnormPack8:           "R4g4UnormPack8",
+	vk.FormatR4g4b4a4UnormPack16:      "R4g4b4a4UnormPack16",
+	vk.FormatB4g4r4a4UnormPack16:      "B4g4r4a4UnormPack16",
+	vk.FormatR5g6b5UnormPack16:        "R5g6b5UnormPack16",
+	vk.FormatB5g6r5UnormPack16:        "B5g6r5UnormPack16",
+	vk.FormatR5g5b5a1UnormPack16:      "R5g5b5a1UnormPack16",
+	vk.FormatB5g5r5a1UnormPack16:      "B5g5r5a1UnormPack16",
+	vk.FormatA1r5g5b5UnormPack16:      "A1r5g5b5UnormPack16",
+	vk.FormatR8Unorm:                  "R8Unorm",
+	vk.FormatR8Snorm:                  "R8Snorm",
+	vk.FormatR8Uscaled:                "R8Uscaled",
+	vk.FormatR8Sscaled:                "R8Sscaled",
+	vk.FormatR8Uint:                   "R8Uint",
+	vk.FormatR8Sint:                   "R8Sint",
+	vk.FormatR8Srgb:                   "R8Srgb",
+	vk.FormatR8g8Unorm:                "R8g8Unorm",
+	vk.FormatR8g8Snorm:                "R8g8Snorm",
+	vk.FormatR8g8Uscaled:              "R8g8Uscaled",
+	vk.FormatR8g8Sscaled:              "R8g8Sscaled",
+	vk.FormatR8g8Uint:                 "R8g8Uint",
+	vk.FormatR8g8Sint:                 "R8g8Sint",
+	vk.FormatR8g8Srgb:                 "R8g8Srgb",
+	vk.FormatR8g8b8Unorm:              "R8g8b8Unorm",
+	vk.FormatR8g8b8Snorm:              "R8g8b8Snorm",
+	vk.FormatR8g8b8Uscaled:            "R8g8b8Uscaled",
+	vk.FormatR8g8b8Sscaled:            "R8g8b8Sscaled",
+	vk.FormatR8g8b8Uint:               "R8g8b8Uint",
+	vk.FormatR8g8b8Sint:               "R8g8b8Sint",
+	vk.FormatR8g8b8Srgb:               "R8g8b8Srgb",
+	vk.FormatB8g8r8Unorm:              "B8g8r8Unorm",
+	vk.FormatB8g8r8Snorm:              "B8g8r8Snorm",
+	vk.FormatB8g8r8Uscaled:            "B8g8r8Uscaled",
+	vk.FormatB8g8r8Sscaled:            "B8g8r8Sscaled",
+	vk.FormatB8g8r8Uint:               "B8g8r8Uint",
+	vk.FormatB8g8r8Sint:               "B8g8r8Sint",
+	vk.FormatB8g8r8Srgb:               "B8g8r8Srgb",
+	vk.FormatR8g8b8a8Unorm:            "R8g8b8a8Unorm",
+	vk.FormatR8g8b8a8Snorm:            "R8g8b8a8Snorm",
+	vk.FormatR8g8b8a8Uscaled:          "R8g8b8a8Uscaled",
+	vk.FormatR8g8b8a8Sscaled:          "R8g8b8a8Sscaled",
+	vk.FormatR8g8b8a8Uint:             "R8g8b8a8Uint",
+	vk.FormatR8g8b8a8Sint:             "R8g8b8a8Sint",
+	vk.FormatR8g8b8a8Srgb:             "R8g8b8a8Srgb",
+	vk.FormatB8g8r8a8Unorm:            "B8g8r8a8Unorm",
+	vk.FormatB8g8r8a8Snorm:            "B8g8r8a8Snorm",
+	vk.FormatB8g8r8a8Uscaled:          "B8g8r8a8Uscaled",
+	vk.FormatB8g8r8a8Sscaled:          "B8g8r8a8Sscaled",
+	vk.FormatB8g8r8a8Uint:             "B8g8r8a8Uint",
+	vk.FormatB8g8r8a8Sint:             "B8g8r8a8Sint",
+	vk.FormatB8g8r8a8Srgb:             "B8g8r8a8Srgb",
+	vk.FormatA8b8g8r8UnormPack32:      "A8b8g8r8UnormPack32",
+	vk.FormatA8b8g8r8SnormPack32:      "A8b8g8r8SnormPack32",
+	vk.FormatA8b8g8r8UscaledPack32:    "A8b8g8r8UscaledPack32",
+	vk.FormatA8b8g8r8SscaledPack32:    "A8b8g8r8SscaledPack32",
+	vk.FormatA8b8g8r8UintPack32:       "A8b8g8r8UintPack32",
+	vk.FormatA8b8g8r8SintPack32:       "A8b8g8r8SintPack32",
+	vk.FormatA8b8g8r8SrgbPack32:       "A8b8g8r8SrgbPack32",
+	vk.FormatA2r10g10b10UnormPack32:   "A2r10g10b10UnormPack32",
+	vk.FormatA2r10g10b10SnormPack32:   "A2r10g10b10SnormPack32",
+	vk.FormatA2r10g10b10UscaledPack32: "A2r10g10b10UscaledPack32",
+	vk.FormatA2r10g10b10SscaledPack32: "A2r10g10b10SscaledPack32",
+	vk.FormatA2r10g10b10UintPack32:    "A2r10g10b10UintPack32",
+	vk.FormatA2r10g10b10SintPack32:    "A2r10g10b10SintPack32",
+	vk.FormatA2b10g10r10UnormPack32:   "A2b10g10r10UnormPack32",
+	vk.FormatA2b10g10r10SnormPack32:   "A2b10g10r10SnormPack32",
+	vk.FormatA2b10g10r10UscaledPack32: "A2b10g10r10UscaledPack32",
+	vk.FormatA2b10g10r10SscaledPack32: "A2b10g10r10SscaledPack32",
+	vk.FormatA2b10g10r10UintPack32:    "A2b10g10r10UintPack32",
+	vk.FormatA2b10g10r10SintPack32:    "A2b10g10r10SintPack32",
+	vk.FormatR16Unorm:                 "R16Unorm",
+	vk.FormatR16Snorm:                 "R16Snorm",
+	vk.FormatR16Uscaled:               "R16Uscaled",
+	vk.FormatR16Sscaled:               "R16Sscaled",
+	vk.FormatR16Uint:                  "R16Uint",
+	vk.FormatR16Sint:                  "R16Sint",
+	vk.FormatR16Sfloat:                "R16Sfloat",
+	vk.FormatR16g16Unorm:              "R16g16Unorm",
+	vk.FormatR16g16Snorm:              "R16g16Snorm",
+	vk.FormatR16g16Uscaled:            "R16g16Uscaled",
+	vk.FormatR16g16Sscaled:            "R16g16Sscaled",
+	vk.FormatR16g16Uint:               "R16g16Uint",
+	vk.FormatR16g16Sint:               "R16g16Sint",
+	vk.FormatR16g16Sfloat:             "R16g16Sfloat",
+	vk.FormatR16g16b16Unorm:           "R16g16b16Unorm",
+	vk.FormatR16g16b16Snorm:           "R16g16b16Snorm",
+	vk.FormatR16g16b16Uscaled:         "R16g16b16Uscaled",
+	vk.FormatR16g16b16Sscaled:         "R16g16b16Sscaled",
+	vk.FormatR16g16b16Uint:            "R16g16b16Uint",
+	vk.FormatR16g16b16Sint:            "R16g16b16Sint",
+	vk.FormatR16g16b16Sfloat:          "R16g16b16Sfloat",
+	vk.FormatR16g16b16a16Unorm:        "R16g16b16a16Unorm",
+	vk.FormatR16g16b16a16Snorm:        "R16g16b16a16Snorm",
+	vk.FormatR16g16b16a16Uscaled:      "R16g16b16a16Uscaled",
+	vk.FormatR16g16b16a16Sscaled:      "R16g16b16a16Sscaled",
+	vk.FormatR16g16b16a16Uint:         "R16g16b16a16Uint",
+	vk.FormatR16g16b16a16Sint:         "R16g16b16a16Sint",
+	vk.FormatR16g16b16a16Sfloat:       "R16g16b16a16Sfloat",
+	vk.FormatR32Uint:                  "R32Uint",
+	vk.FormatR32Sint:                  "R32Sint",
+	vk.FormatR32Sfloat:                "R32Sfloat",
+	vk.FormatR32g32Uint:               "R32g32Uint",
+	vk.FormatR32g32Sint:               "R32g32Sint",
+	vk.FormatR32g32Sfloat:             "R32g32Sfloat",
+	vk.FormatR32g32b32Uint:            "R32g32b32Uint",
+	vk.FormatR32g32b32Sint:            "R32g32b32Sint",
+	vk.FormatR32g32b32Sfloat:          "R32g32b32Sfloat",
+	vk.FormatR32g32b32a32Uint:         "R32g32b32a32Uint",
+	vk.FormatR32g32b32a32Sint:         "R32g32b32a32Sint",
+	vk.FormatR32g32b32a32Sfloat:       "R32g32b32a32Sfloat",
+	vk.FormatR64Uint:                  "R64Uint",
+	vk.FormatR64Sint:                  "R64Sint",
+	vk.FormatR64Sfloat:                "R64Sfloat",
+	vk.FormatR64g64Uint:               "R64g64Uint",
+	vk.FormatR64g64Sint:               "R64g64Sint",
+	vk.FormatR64g64Sfloat:             "R64g64Sfloat",
+	vk.FormatR64g64b64Uint:            "R64g64b64Uint",
+	vk.FormatR64g64b64Sint:            "R64g64b64Sint",
+	vk.FormatR64g64b64Sfloat:          "R64g64b64Sfloat",
+	vk.FormatR64g64b64a64Uint:         "R64g64b64a64Uint",
+	vk.FormatR64g64b64a64Sint:         "R64g64b64a64Sint",
+	vk.FormatR64g64b64a64Sfloat:       "R64g64b64a64Sfloat",
+	vk.FormatB10g11r11UfloatPack32:    "B10g11r11UfloatPack32",
+	vk.FormatE5b9g9r9UfloatPack32:     "E5b9g9r9UfloatPack32",
+	vk.FormatD16Unorm:                 "D16Unorm",
+	vk.FormatX8D24UnormPack32:         "X8D24UnormPack32",
+	vk.FormatD32Sfloat:                "D32Sfloat",
+	vk.FormatS8Uint:                   "S8Uint",
+	vk.FormatD16UnormS8Uint:           "D16UnormS8Uint",
+	vk.FormatD24UnormS8Uint:           "D24UnormS8Uint",
+	vk.FormatD32SfloatS8Uint:          "D32SfloatS8Uint",
+	vk.FormatBc1RgbUnormBlock:         "Bc1RgbUnormBlock",
+	vk.FormatBc1RgbSrgbBlock:          "Bc1RgbSrgbBlock",
+	vk.FormatBc1RgbaUnormBlock:        "Bc1RgbaUnormBlock",
+	vk.FormatBc1RgbaSrgbBlock:         "Bc1RgbaSrgbBlock",
+	vk.FormatBc2UnormBlock:            "Bc2UnormBlock",
+	vk.FormatBc2SrgbBlock:             "Bc2SrgbBlock",
+	vk.FormatBc3UnormBlock:            "Bc3UnormBlock",
+	vk.FormatBc3SrgbBlock:             "Bc3SrgbBlock",
+	vk.FormatBc4UnormBlock:            "Bc4UnormBlock",
+	vk.FormatBc4SnormBlock:            "Bc4SnormBlock",
+	vk.FormatBc5UnormBlock:            "Bc5UnormBlock",
+	vk.FormatBc5SnormBlock:            "Bc5SnormBlock",
+	vk.FormatBc6hUfloatBlock:          "Bc6hUfloatBlock",
+	vk.FormatBc6hSfloatBlock:          "Bc6hSfloatBlock",
+	vk.FormatBc7UnormBlock:            "Bc7UnormBlock",
+	vk.FormatBc7SrgbBlock:             "Bc7SrgbBlock",
+	vk.FormatEtc2R8g8b8UnormBlock:     "Etc2R8g8b8UnormBlock",
+	vk.FormatEtc2R8g8b8SrgbBlock:      "Etc2R8g8b8SrgbBlock",
+	vk.FormatEtc2R8g8b8a1UnormBlock:   "Etc2R8g8b8a1UnormBlock",
+	vk.FormatEtc2R8g8b8a1SrgbBlock:    "Etc2R8g8b8a1SrgbBlock",
+	vk.FormatEtc2R8g8b8a8UnormBlock:   "Etc2R8g8b8a8UnormBlock",
+	vk.FormatEtc2R8g8b8a8SrgbBlock:    "Etc2R8g8b8a8SrgbBlock",
+	vk.FormatEacR11UnormBlock:         "EacR11UnormBlock",
+	vk.FormatEacR11SnormBlock:         "EacR11SnormBlock",
+	vk.FormatEacR11g11UnormBlock:      "EacR11g11UnormBlock",
+	vk.FormatEacR11g11SnormBlock:      "EacR11g11SnormBlock",
+	vk.FormatAstc4x4UnormBlock:        "Astc4x4UnormBlock",
+	vk.FormatAstc4x4SrgbBlock:         "Astc4x4SrgbBlock",
+	vk.FormatAstc5x4UnormBlock:        "Astc5x4UnormBlock",
+	vk.FormatAstc5x4SrgbBlock:         "Astc5x4SrgbBlock",
+	vk.FormatAstc5x5UnormBlock:        "Astc5x5UnormBlock",
+	vk.FormatAstc5x5SrgbBlock:         "Astc5x5SrgbBlock",
+	vk.FormatAstc6x5UnormBlock:        "Astc6x5UnormBlock",
+	vk.FormatAstc6x5SrgbBlock:         "Astc6x5SrgbBlock",
+	vk.FormatAstc6x6UnormBlock:        "Astc6x6UnormBlock",
+	vk.FormatAstc6x6SrgbBlock:         "Astc6x6SrgbBlock",
+	vk.FormatAstc8x5UnormBlock:        "Astc8x5UnormBlock",
+	vk.FormatAstc8x5SrgbBlock:         "Astc8x5SrgbBlock",
+	vk.FormatAstc8x6UnormBlock:        "Astc8x6UnormBlock",
+	vk.FormatAstc8x6SrgbBlock:         "Astc8x6SrgbBlock",
+	vk.FormatAstc8x8UnormBlock:        "Astc8x8UnormBlock",
+	vk.FormatAstc8x8SrgbBlock:         "Astc8x8SrgbBlock",
+	vk.FormatAstc10x5UnormBlock:       "Astc10x5UnormBlock",
+	vk.FormatAstc10x5SrgbBlock:        "Astc10x5SrgbBlock",
+	vk.FormatAstc10x6UnormBlock:       "Astc10x6UnormBlock",
+	vk.FormatAstc10x6SrgbBlock:        "Astc10x6SrgbBlock",
+	vk.FormatAstc10x8UnormBlock:       "Astc10x8UnormBlock",
+	vk.FormatAstc10x8SrgbBlock:        "Astc10x8SrgbBlock",
+	vk.FormatAstc10x10UnormBlock:      "Astc10x10UnormBlock",
+	vk.FormatAstc10x10SrgbBlock:       "Astc10x10SrgbBlock",
+	vk.FormatAstc12x10UnormBlock:      "Astc12x10UnormBlock",
+	vk.FormatAstc12x10SrgbBlock:       "Astc12x10SrgbBlock",
+	vk.FormatAstc12x12UnormBlock:      "Astc12x12UnormBlock",
+	vk.FormatAstc12x12SrgbBlock:       "Astc12x12SrgbBlock",
+}
+
+var formatFeatureFlagNames = []flagName{
+	{uint32(vk.FormatFeatureSampledImageBit), "SampledImage"},
+	{uint32(vk.FormatFeatureStorageImageBit), "StorageImage"},
+	{uint32(vk.FormatFeatureStorageImageAtomicBit), "StorageImageAtomic"},
+	{uint32(vk.FormatFeatureUniformTexelBufferBit), "UniformTexelBuffer"},
+	{uint32(vk.FormatFeatureStorageTexelBufferBit), "StorageTexelBuffer"},
+	{uint32(vk.FormatFeatureStorageTexelBufferAtomicBit), "StorageTexelBufferAtomic"},
+	{uint32(vk.FormatFeatureVertexBufferBit), "VertexBuffer"},
+	{uint32(vk.FormatFeatureColorAttachmentBit), "ColorAttachment"},
+	{uint32(vk.FormatFeatureColorAttachmentBlendBit), "ColorAttachmentBlend"},
+	{uint32(vk.FormatFeatureDepthStencilAttachmentBit), "DepthStencilAttachment"},
+	{uint32(vk.FormatFeatureBlitSrcBit), "BlitSrc"},
+	{uint32(vk.FormatFeatureBlitDstBit), "BlitDst"},
+	{uint32(vk.FormatFeatureSampledImageFilterLinearBit), "SampledImageFilterLinear"},
+	{uint32(vk.FormatFeatureSampledImageFilterCubicBitImg), "SampledImageFilterCubicImg"},
+	{uint32(vk.FormatFeatureTransferSrcBit), "TransferSrc"},
+	{uint32(vk.FormatFeatureTransferDstBit), "TransferDst"},
+	{uint32(vk.FormatFeatureSampledImageFilterMinmaxBit), "SampledImageFilterMinmax"},
+	{uint32(vk.FormatFeatureMidpointChromaSamplesBit), "MidpointChromaSamples"},
+	{uint32(vk.FormatFeatureSampledImageYcbcrConversionLinearFilterBit), "SampledImageYcbcrConversionLinearFilter"},
+	{uint32(vk.FormatFeatureSampledImageYcbcrConversionSeparateReconstructionFilterBit), "SampledImageYcbcrConversionSeparateReconstructionFilter"},
+	{uint32(vk.FormatFeatureSampledImageYcbcrConversionChromaReconstructionExplicitBit), "SampledImageYcbcrConversionChromaReconstructionExplicit"},
+	{uint32(vk.FormatFeatureSampledImageYcbcrConversionChromaReconstructionExplicitForceableBit), "SampledImageYcbcrConversionChromaReconstructionExplicitForceable"},
+	{uint32(vk.FormatFeatureDisjointBit), "Disjoint"},
+	{uint32(vk.FormatFeatureCositedChromaSamplesBit), "CositedChromaSamples"},
+}
+
+var colorSpaceNames = map[vk.ColorSpace]string{
+	vk.ColorSpaceSrgbNonlinear:         "SrgbNonlinear",
+	vk.ColorSpaceDisplayP3Nonlinear:    "DisplayP3Nonlinear",
+	vk.ColorSpaceExtendedSrgbLinear:    "ExtendedSrgbLinear",
+	vk.ColorSpaceDciP3Linear:           "DciP3Linear",
+	vk.ColorSpaceDciP3Nonlinear:        "DciP3Nonlinear",
+	vk.ColorSpaceBt709Linear:           "Bt709Linear",
+	vk.ColorSpaceBt709Nonlinear:        "Bt709Nonlinear",
+	vk.ColorSpaceBt2020Linear:          "Bt2020Linear",
+	vk.ColorSpaceHdr10St2084:           "Hdr10St2084",
+	vk.ColorSpaceDolbyvision:           "Dolbyvision",
+	vk.ColorSpaceHdr10Hlg:              "Hdr10Hlg",
+	vk.ColorSpaceAdobergbLinear:        "AdobergbLinear",
+	vk.ColorSpaceAdobergbNonlinear:     "AdobergbNonlinear",
+	vk.ColorSpacePassThrough:           "PassThrough",
+	vk.ColorSpaceExtendedSrgbNonlinear: "ExtendedSrgbNonlinear",
+}
+
+var presentModeNames = map[vk.PresentMode]string{
+	vk.PresentModeImmediate:               "Immediate",
+	vk.PresentModeMailbox:                 "Mailbox",
+	vk.PresentModeFifo:                    "Fifo",
+	vk.PresentModeFifoRelaxed:             "FifoRelaxed",
+	vk.PresentModeSharedDemandRefresh:     "SharedDemandRefresh",
+	vk.PresentModeSharedContinuousRefresh: "SharedContinuousRefresh",
+}
+
+func formatName(format vk.Format) string {
+	if name, ok := formatNames[format]; ok {
+		return name
+	}
+	return fmt.Sprintf("Format(%d)", format)
+}
+
+func colorSpaceName(colorSpace vk.ColorSpace) string {
+	if name, ok := colorSpaceNames[colorSpace]; ok {
+		return name
+	}
+	return fmt.Sprintf("ColorSpace(%d)", colorSpace)
+}
+
+func presentModeName(mode vk.PresentMode) string {
+	if name, ok := presentModeNames[mode]; ok {
+		return name
+	}
+	return fmt.Sprintf("PresentMode(%d)", mode)
+}
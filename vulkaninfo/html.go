@@ -0,0 +1,214 @@
+package vulkaninfo
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"reflect"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// WriteHTML renders the report as a self-contained HTML page,
+// every section is collapsible so it stays readable when attached to bug reports.
+func WriteHTML(w io.Writer, r *Report) error {
+	return htmlTemplate.Execute(w, r)
+}
+
+type htmlField struct {
+	Name  string
+	Value string
+}
+
+// htmlFields lists the fields of a struct or a pointer to a struct, in declaration order.
+func htmlFields(v interface{}) []htmlField {
+	rv := reflect.Indirect(reflect.ValueOf(v))
+	rt := rv.Type()
+	var fields []htmlField
+	for i := 0; i < rt.NumField(); i++ {
+		fields = append(fields, htmlField{
+			Name:  rt.Field(i).Name,
+			Value: fmt.Sprintf("%v", rv.Field(i).Interface()),
+		})
+	}
+	return fields
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"fields":     htmlFields,
+	"joinFlags":  joinFlags,
+	"formatSize": formatSize,
+	"hex":        func(v uint32) string { return fmt.Sprintf("%x", v) },
+	"version":    func(v uint32) string { return vk.Version(v).String() },
+	"titled": func(title string, extensions []Extension) interface{} {
+		return struct {
+			Title      string
+			Extensions []Extension
+		}{title, extensions}
+	},
+}).Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Vulkan Info</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; }
+details { margin: 4px 0 4px 16px; }
+summary { cursor: pointer; font-weight: bold; }
+table { border-collapse: collapse; margin: 4px 0; }
+td, th { border: 1px solid #ccc; padding: 2px 8px; text-align: left; vertical-align: top; }
+</style>
+</head>
+<body>
+<h1>Vulkan Info</h1>
+<p>Physical devices: {{.PhysicalDevices}}</p>
+{{template "extensions" titled "Instance extensions" .InstanceExtensions}}
+{{- if .InstanceLayers}}
+<details>
+<summary>Instance layers ({{len .InstanceLayers}})</summary>
+{{template "layers" .InstanceLayers}}
+</details>
+{{- end}}
+{{- range .Devices}}
+<details open>
+<summary>Device #{{.Index}}: {{.DeviceName}}</summary>
+<table>
+<tr><th>Vendor</th><td>{{.VendorName}} ({{hex .VendorID}})</td></tr>
+<tr><th>Device ID</th><td>{{hex .DeviceID}}</td></tr>
+<tr><th>Device type</th><td>{{.DeviceType}}</td></tr>
+<tr><th>API version</th><td>{{.APIVersion}}</td></tr>
+<tr><th>Driver version</th><td>{{.DriverVersion}}</td></tr>
+{{- with .Driver}}
+<tr><th>Driver ID</th><td>{{.DriverID}}</td></tr>
+<tr><th>Driver name</th><td>{{.DriverName}}</td></tr>
+<tr><th>Driver info</th><td>{{.DriverInfo}}</td></tr>
+<tr><th>Conformance version</th><td>{{.ConformanceVersion}}</td></tr>
+{{- end}}
+</table>
+{{template "extensions" titled "Device extensions" .Extensions}}
+{{- if .Layers}}
+<details>
+<summary>Device layers ({{len .Layers}})</summary>
+{{template "layers" .Layers}}
+</details>
+{{- end}}
+<details>
+<summary>Limits</summary>
+{{template "fields" .Limits}}
+</details>
+<details>
+<summary>Sparse properties</summary>
+{{template "fields" .SparseProperties}}
+</details>
+{{- with .Vulkan11Features}}
+<details>
+<summary>Vulkan 1.1 features</summary>
+{{template "fields" .}}
+</details>
+{{- end}}
+{{- with .Vulkan12Features}}
+<details>
+<summary>Vulkan 1.2 features</summary>
+{{template "fields" .}}
+</details>
+{{- end}}
+{{- with .Vulkan13Features}}
+<details>
+<summary>Vulkan 1.3 features</summary>
+{{template "fields" .}}
+</details>
+{{- end}}
+{{- with .Subgroup}}
+<details>
+<summary>Subgroup properties</summary>
+<table>
+<tr><th>SubgroupSize</th><td>{{.SubgroupSize}}</td></tr>
+<tr><th>SupportedStages</th><td>{{joinFlags .SupportedStages}}</td></tr>
+<tr><th>SupportedOperations</th><td>{{joinFlags .SupportedOperations}}</td></tr>
+<tr><th>QuadOperationsInAllStages</th><td>{{.QuadOperationsInAllStages}}</td></tr>
+</table>
+</details>
+{{- end}}
+{{- with .DescriptorIndexing}}
+<details>
+<summary>Descriptor indexing</summary>
+{{template "fields" .Features}}
+{{template "fields" .Properties}}
+</details>
+{{- end}}
+<details>
+<summary>Formats ({{len .Formats}})</summary>
+<table>
+<tr><th>Format</th><th>Linear tiling</th><th>Optimal tiling</th><th>Buffer</th></tr>
+{{- range .Formats}}
+<tr><td>{{.Format}}</td><td>{{joinFlags .LinearTilingFeatures}}</td><td>{{joinFlags .OptimalTilingFeatures}}</td><td>{{joinFlags .BufferFeatures}}</td></tr>
+{{- end}}
+</table>
+</details>
+<details>
+<summary>Queue families ({{len .QueueFamilies}})</summary>
+<table>
+<tr><th>#</th><th>Flags</th><th>Queue count</th><th>Timestamp valid bits</th><th>Min image transfer granularity</th><th>Present support</th></tr>
+{{- range $i, $f := .QueueFamilies}}
+<tr><td>{{$i}}</td><td>{{joinFlags .QueueFlags}}</td><td>{{.QueueCount}}</td><td>{{.TimestampValidBits}}</td><td>{{with .MinImageTransferGranularity}}{{.Width}}x{{.Height}}x{{.Depth}}{{end}}</td><td>{{with .SupportsPresent}}{{.}}{{else}}n/a{{end}}</td></tr>
+{{- end}}
+</table>
+</details>
+<details>
+<summary>Memory</summary>
+<table>
+<tr><th>Heap</th><th>Size</th><th>Flags</th></tr>
+{{- range $i, $h := .MemoryHeaps}}
+<tr><td>{{$i}}</td><td>{{formatSize .Size}}</td><td>{{joinFlags .Flags}}</td></tr>
+{{- end}}
+</table>
+<table>
+<tr><th>Type</th><th>Heap</th><th>Flags</th></tr>
+{{- range $i, $t := .MemoryTypes}}
+<tr><td>{{$i}}</td><td>{{.HeapIndex}}</td><td>{{joinFlags .PropertyFlags}}</td></tr>
+{{- end}}
+</table>
+</details>
+{{- with .Surface}}
+<details>
+<summary>Surface</summary>
+<table>
+<tr><th>Image count</th><td>{{.MinImageCount}} - {{.MaxImageCount}}</td></tr>
+<tr><th>Array layers</th><td>{{.MaxImageArrayLayers}}</td></tr>
+<tr><th>Image size (current)</th><td>{{with .CurrentExtent}}{{.Width}}x{{.Height}}{{end}}</td></tr>
+<tr><th>Image size (extent)</th><td>{{with .MinImageExtent}}{{.Width}}x{{.Height}}{{end}} - {{with .MaxImageExtent}}{{.Width}}x{{.Height}}{{end}}</td></tr>
+<tr><th>Usage flags</th><td>{{joinFlags .SupportedUsageFlags}}</td></tr>
+<tr><th>Current transform</th><td>{{.CurrentTransform}}</td></tr>
+<tr><th>Allowed transforms</th><td>{{joinFlags .SupportedTransforms}}</td></tr>
+<tr><th>Composite alpha</th><td>{{joinFlags .SupportedCompositeAlpha}}</td></tr>
+<tr><th>Present modes</th><td>{{joinFlags .PresentModes}}</td></tr>
+{{- range .Formats}}
+<tr><th>Surface format</th><td>{{.Format}}, {{.ColorSpace}}</td></tr>
+{{- end}}
+</table>
+</details>
+{{- end}}
+</details>
+{{- end}}
+</body>
+</html>
+{{define "extensions"}}<details>
+<summary>{{.Title}} ({{len .Extensions}})</summary>
+<table>
+{{- range .Extensions}}
+<tr><td>{{.Name}}</td><td>rev {{.SpecVersion}}</td></tr>
+{{- end}}
+</table>
+</details>{{end}}
+{{define "layers"}}<table>
+{{- range .}}
+<tr><td>{{.Name}}</td><td>{{version .SpecVersion}} (impl {{.ImplementationVersion}})</td><td>{{.Description}}
+{{- range .Extensions}}<br>{{.Name}} (rev {{.SpecVersion}}){{end}}</td></tr>
+{{- end}}
+</table>{{end}}
+{{define "fields"}}<table>
+{{- range fields .}}
+<tr><th>{{.Name}}</th><td>{{.Value}}</td></tr>
+{{- end}}
+</table>{{end}}
+`))
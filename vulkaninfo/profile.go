@@ -0,0 +1,374 @@
+package vulkaninfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// Profile declares the requirements of an application,
+// every physical device of a report can be checked against it.
+type Profile struct {
+	MinAPIVersion      string   `json:"minApiVersion"`
+	InstanceExtensions []string `json:"instanceExtensions"`
+	DeviceExtensions   []string `json:"deviceExtensions"`
+	// MinLimits is keyed by the JSON name of a DeviceLimits field, see limitKinds
+	// for how the device value is compared with the required one.
+	MinLimits      map[string]json.RawMessage `json:"minLimits"`
+	FormatFeatures []FormatFeatures           `json:"formatFeatures"`
+}
+
+// RequirementResult is the outcome of checking one requirement on a device.
+type RequirementResult struct {
+	Requirement string
+	Passed      bool
+	Detail      string
+}
+
+// DeviceCheck holds the outcome of checking every requirement on a device.
+type DeviceCheck struct {
+	Device  *DeviceReport
+	Results []RequirementResult
+}
+
+// Passed reports whether the device meets all the requirements.
+func (c *DeviceCheck) Passed() bool {
+	for _, r := range c.Results {
+		if !r.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// LoadProfile reads a JSON profile from the file.
+func LoadProfile(path string) (*Profile, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var p Profile
+	if err := json.Unmarshal(data, &p); err != nil {
+		err = fmt.Errorf("failed to read profile %s: %s", path, err)
+		return nil, err
+	}
+	return &p, nil
+}
+
+// CheckProfile evaluates every device of the report against the profile,
+// an error is returned when the profile itself is invalid.
+func CheckProfile(r *Report, p *Profile) ([]*DeviceCheck, error) {
+	var minAPIVersion uint32
+	if len(p.MinAPIVersion) > 0 {
+		var err error
+		if minAPIVersion, err = parseVersion(p.MinAPIVersion); err != nil {
+			return nil, err
+		}
+	}
+	limitFields := jsonFieldIndex(reflect.TypeOf(DeviceLimits{}))
+	for name := range p.MinLimits {
+		if _, ok := limitFields[name]; !ok {
+			err := fmt.Errorf("profile: unknown limit %s", name)
+			return nil, err
+		}
+	}
+
+	var checks []*DeviceCheck
+	for _, d := range r.Devices {
+		c := &DeviceCheck{
+			Device: d,
+		}
+		if len(p.MinAPIVersion) > 0 {
+			apiVersion, err := parseVersion(d.APIVersion)
+			c.Results = append(c.Results, RequirementResult{
+				Requirement: "API version >= " + p.MinAPIVersion,
+				Passed:      err == nil && apiVersion >= minAPIVersion,
+				Detail:      d.APIVersion,
+			})
+		}
+		for _, ext := range p.InstanceExtensions {
+			c.Results = append(c.Results, checkName("instance extension "+ext, ext, extensionNames(r.InstanceExtensions)))
+		}
+		for _, ext := range p.DeviceExtensions {
+			c.Results = append(c.Results, checkName("device extension "+ext, ext, extensionNames(d.Extensions)))
+		}
+		limits := reflect.ValueOf(d.Limits)
+		for _, name := range sortedKeys(p.MinLimits) {
+			field := limits.Field(limitFields[name])
+			result, err := checkLimit(name, field, p.MinLimits[name])
+			if err != nil {
+				return nil, err
+			}
+			c.Results = append(c.Results, result)
+		}
+		for _, req := range p.FormatFeatures {
+			c.Results = append(c.Results, checkFormat(req, d.Formats))
+		}
+		checks = append(checks, c)
+	}
+	return checks, nil
+}
+
+// CheckProfileFile loads the profile from the file, evaluates every device of the report against it
+// and writes the outcome to w. It reports whether at least one device qualifies.
+func (r *Report) CheckProfileFile(w io.Writer, path string) (ok bool, err error) {
+	profile, err := LoadProfile(path)
+	if err != nil {
+		return false, err
+	}
+	checks, err := CheckProfile(r, profile)
+	if err != nil {
+		return false, err
+	}
+	PrintChecks(w, checks)
+	for _, c := range checks {
+		if c.Passed() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// PrintChecks writes pass/fail per requirement for each device.
+func PrintChecks(w io.Writer, checks []*DeviceCheck) {
+	for _, c := range checks {
+		status := "PASS"
+		if !c.Passed() {
+			status = "FAIL"
+		}
+		fmt.Fprintf(w, "%s device #%d %s\n", status, c.Device.Index, c.Device.DeviceName)
+		for _, r := range c.Results {
+			status := "ok  "
+			if !r.Passed {
+				status = "FAIL"
+			}
+			if len(r.Detail) > 0 {
+				fmt.Fprintf(w, "  %s %s (%s)\n", status, r.Requirement, r.Detail)
+			} else {
+				fmt.Fprintf(w, "  %s %s\n", status, r.Requirement)
+			}
+		}
+	}
+}
+
+func checkName(requirement, name string, list []string) RequirementResult {
+	if hasName(list, name) {
+		return RequirementResult{Requirement: requirement, Passed: true}
+	}
+	return RequirementResult{Requirement: requirement, Detail: "not supported"}
+}
+
+// limitKind tells how a device limit is compared with the required value.
+type limitKind int
+
+const (
+	// limitMax is an upper bound of what the device supports, the device value
+	// must be at least the required one, e.g. maxImageDimension2D.
+	limitMax limitKind = iota
+	// limitMin is a lower bound, an alignment or a granularity, the device value
+	// must not exceed the required one, e.g. minUniformBufferOffsetAlignment or minTexelOffset.
+	limitMin
+	// limitBitmask is a set of flags, the device must have all the required bits,
+	// e.g. framebufferColorSampleCounts.
+	limitBitmask
+	// limitRange is a [min, max] pair, the device range must cover the required one,
+	// e.g. pointSizeRange.
+	limitRange
+)
+
+func (k limitKind) op() string {
+	switch k {
+	case limitMin:
+		return "<="
+	case limitBitmask:
+		return "has"
+	case limitRange:
+		return "covers"
+	default:
+		return ">="
+	}
+}
+
+// limitKinds lists every limit that is not a limitMax,
+// following the limit types of the Required Limits table of the Vulkan spec.
+var limitKinds = map[string]limitKind{
+	"bufferImageGranularity":             limitMin,
+	"minMemoryMapAlignment":              limitMin,
+	"minTexelBufferOffsetAlignment":      limitMin,
+	"minUniformBufferOffsetAlignment":    limitMin,
+	"minStorageBufferOffsetAlignment":    limitMin,
+	"minTexelOffset":                     limitMin,
+	"minTexelGatherOffset":               limitMin,
+	"minInterpolationOffset":             limitMin,
+	"timestampPeriod":                    limitMin,
+	"pointSizeGranularity":               limitMin,
+	"lineWidthGranularity":               limitMin,
+	"optimalBufferCopyOffsetAlignment":   limitMin,
+	"optimalBufferCopyRowPitchAlignment": limitMin,
+	"nonCoherentAtomSize":                limitMin,
+
+	"framebufferColorSampleCounts":         limitBitmask,
+	"framebufferDepthSampleCounts":         limitBitmask,
+	"framebufferStencilSampleCounts":       limitBitmask,
+	"framebufferNoAttachmentsSampleCounts": limitBitmask,
+	"sampledImageColorSampleCounts":        limitBitmask,
+	"sampledImageIntegerSampleCounts":      limitBitmask,
+	"sampledImageDepthSampleCounts":        limitBitmask,
+	"sampledImageStencilSampleCounts":      limitBitmask,
+	"storageImageSampleCounts":             limitBitmask,
+
+	"viewportBoundsRange": limitRange,
+	"pointSizeRange":      limitRange,
+	"lineWidthRange":      limitRange,
+}
+
+func checkLimit(name string, field reflect.Value, raw json.RawMessage) (RequirementResult, error) {
+	kind := limitKinds[name]
+	result := RequirementResult{
+		Requirement: fmt.Sprintf("limit %s %s %s", name, kind.op(), string(raw)),
+		Detail:      fmt.Sprintf("%v", field.Interface()),
+	}
+	// the required value must have the same shape as the limit itself
+	required := reflect.New(field.Type())
+	if err := json.Unmarshal(raw, required.Interface()); err != nil {
+		err = fmt.Errorf("profile: invalid value for limit %s: %s", name, err)
+		return result, err
+	}
+	result.Passed = limitSatisfies(field, required.Elem(), kind)
+	return result, nil
+}
+
+func limitSatisfies(have, want reflect.Value, kind limitKind) bool {
+	switch have.Kind() {
+	case reflect.Array:
+		for i := 0; i < have.Len(); i++ {
+			elemKind := kind
+			if kind == limitRange {
+				// the lower end of the range must reach down to the required one
+				elemKind = limitMax
+				if i == 0 {
+					elemKind = limitMin
+				}
+			}
+			if !limitSatisfies(have.Index(i), want.Index(i), elemKind) {
+				return false
+			}
+		}
+		return true
+	case reflect.Bool:
+		return have.Bool() || !want.Bool()
+	case reflect.Uint32, reflect.Uint64:
+		switch kind {
+		case limitBitmask:
+			return have.Uint()&want.Uint() == want.Uint()
+		case limitMin:
+			return have.Uint() <= want.Uint()
+		}
+		return have.Uint() >= want.Uint()
+	case reflect.Int32:
+		if kind == limitMin {
+			return have.Int() <= want.Int()
+		}
+		return have.Int() >= want.Int()
+	case reflect.Float32:
+		if kind == limitMin {
+			return have.Float() <= want.Float()
+		}
+		return have.Float() >= want.Float()
+	}
+	return false
+}
+
+func checkFormat(req FormatFeatures, formats []FormatFeatures) RequirementResult {
+	result := RequirementResult{
+		Requirement: "format " + req.Format,
+	}
+	var have FormatFeatures
+	for _, f := range formats {
+		if f.Format == req.Format {
+			have = f
+			break
+		}
+	}
+	var missing []string
+	for _, feature := range req.LinearTilingFeatures {
+		if !hasName(have.LinearTilingFeatures, feature) {
+			missing = append(missing, "linear "+feature)
+		}
+	}
+	for _, feature := range req.OptimalTilingFeatures {
+		if !hasName(have.OptimalTilingFeatures, feature) {
+			missing = append(missing, "optimal "+feature)
+		}
+	}
+	for _, feature := range req.BufferFeatures {
+		if !hasName(have.BufferFeatures, feature) {
+			missing = append(missing, "buffer "+feature)
+		}
+	}
+	if len(missing) > 0 {
+		result.Detail = "missing " + strings.Join(missing, ", ")
+		return result
+	}
+	result.Passed = true
+	return result
+}
+
+func hasName(list []string, name string) bool {
+	for _, s := range list {
+		if s == name {
+			return true
+		}
+	}
+	return false
+}
+
+// parseVersion parses major.minor[.patch] into the packed Vulkan version.
+func parseVersion(s string) (uint32, error) {
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		err := fmt.Errorf("invalid version %q", s)
+		return 0, err
+	}
+	// the largest value of each field packed by vk.MakeVersion
+	limits := [3]int{1<<10 - 1, 1<<10 - 1, 1<<12 - 1}
+	var nums [3]int
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || n > limits[i] {
+			err := fmt.Errorf("invalid version %q", s)
+			return 0, err
+		}
+		nums[i] = n
+	}
+	return vk.MakeVersion(nums[0], nums[1], nums[2]), nil
+}
+
+// jsonFieldIndex maps the JSON names of the struct fields to their indices.
+func jsonFieldIndex(rt reflect.Type) map[string]int {
+	fields := make(map[string]int, rt.NumField())
+	for i := 0; i < rt.NumField(); i++ {
+		name := strings.Split(rt.Field(i).Tag.Get("json"), ",")[0]
+		if len(name) == 0 {
+			name = rt.Field(i).Name
+		}
+		fields[name] = i
+	}
+	return fields
+}
+
+func sortedKeys(m map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
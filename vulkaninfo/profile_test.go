@@ -0,0 +1,188 @@
+package vulkaninfo
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+func TestCheckLimit(t *testing.T) {
+	limits := DeviceLimits{
+		MaxImageDimension2D:             16384,
+		MaxComputeWorkGroupSize:         [3]uint32{1024, 1024, 64},
+		MinUniformBufferOffsetAlignment: 256,
+		MinTexelOffset:                  -8,
+		BufferImageGranularity:          1024,
+		NonCoherentAtomSize:             64,
+		PointSizeGranularity:            0.125,
+		FramebufferColorSampleCounts:    0x1 | 0x4 | 0x8, // 1, 4 and 8 samples
+		PointSizeRange:                  [2]float32{1, 64},
+		ViewportBoundsRange:             [2]float32{-32768, 32767},
+		StrictLines:                     true,
+	}
+	tests := []struct {
+		name     string
+		required string
+		passed   bool
+	}{
+		// max limits, at least the required value
+		{"maxImageDimension2D", "8192", true},
+		{"maxImageDimension2D", "16384", true},
+		{"maxImageDimension2D", "32768", false},
+		{"maxComputeWorkGroupSize", "[1024, 1024, 64]", true},
+		{"maxComputeWorkGroupSize", "[256, 256, 128]", false},
+		// min limits, alignments and granularities, at most the required value
+		{"minUniformBufferOffsetAlignment", "256", true},
+		{"minUniformBufferOffsetAlignment", "64", false},
+		{"minTexelOffset", "-8", true},
+		{"minTexelOffset", "-16", false},
+		{"bufferImageGranularity", "4096", true},
+		{"bufferImageGranularity", "1", false},
+		{"nonCoherentAtomSize", "256", true},
+		{"nonCoherentAtomSize", "16", false},
+		{"pointSizeGranularity", "1", true},
+		{"pointSizeGranularity", "0.0625", false},
+		// bitmasks, all the required bits
+		{"framebufferColorSampleCounts", "4", true},
+		{"framebufferColorSampleCounts", "9", true},
+		{"framebufferColorSampleCounts", "2", false},
+		{"framebufferColorSampleCounts", "16", false},
+		// ranges, the device range covers the required one
+		{"pointSizeRange", "[1, 64]", true},
+		{"pointSizeRange", "[2, 32]", true},
+		{"pointSizeRange", "[0.5, 32]", false},
+		{"pointSizeRange", "[1, 128]", false},
+		{"viewportBoundsRange", "[-8192, 8191]", true},
+		{"viewportBoundsRange", "[-65536, 8191]", false},
+		// flags
+		{"strictLines", "true", true},
+		{"standardSampleLocations", "true", false},
+		{"standardSampleLocations", "false", true},
+	}
+	fields := jsonFieldIndex(reflect.TypeOf(limits))
+	values := reflect.ValueOf(limits)
+	for _, test := range tests {
+		field := values.Field(fields[test.name])
+		result, err := checkLimit(test.name, field, json.RawMessage(test.required))
+		if err != nil {
+			t.Errorf("%s %s: %s", test.name, test.required, err)
+			continue
+		}
+		if result.Passed != test.passed {
+			t.Errorf("%s: got passed %v, want %v (device %s)",
+				result.Requirement, result.Passed, test.passed, result.Detail)
+		}
+	}
+
+	field := values.Field(fields["pointSizeRange"])
+	if _, err := checkLimit("pointSizeRange", field, json.RawMessage("64")); err == nil {
+		t.Error("a scalar is accepted for a range limit")
+	}
+}
+
+// TestLimitKinds makes sure the table only names existing limits
+// and that no alignment or lower bound is left compared as a maximum.
+func TestLimitKinds(t *testing.T) {
+	fields := jsonFieldIndex(reflect.TypeOf(DeviceLimits{}))
+	for name := range limitKinds {
+		if _, ok := fields[name]; !ok {
+			t.Errorf("limitKinds lists an unknown limit %s", name)
+		}
+	}
+	for name := range fields {
+		if limitKinds[name] != limitMax {
+			continue
+		}
+		// maxUniformBufferRange and maxStorageBufferRange are sizes, not ranges
+		isRange := strings.HasSuffix(name, "Range") && !strings.HasSuffix(name, "BufferRange")
+		if isRange || strings.HasPrefix(name, "min") || strings.HasSuffix(name, "Alignment") ||
+			strings.HasSuffix(name, "Granularity") || strings.HasSuffix(name, "SampleCounts") {
+			t.Errorf("limit %s is compared as a maximum", name)
+		}
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	tests := []struct {
+		s    string
+		want uint32
+		ok   bool
+	}{
+		{"1.3", vk.MakeVersion(1, 3, 0), true},
+		{"1.2.198", vk.MakeVersion(1, 2, 198), true},
+		{"1.1023.4095", vk.MakeVersion(1, 1023, 4095), true},
+		{"1.1024", 0, false},
+		{"1.2.4096", 0, false},
+		{"1024.0", 0, false},
+		{"1", 0, false},
+		{"1.2.3.4", 0, false},
+		{"1.-2", 0, false},
+		{"1.x", 0, false},
+	}
+	for _, test := range tests {
+		got, err := parseVersion(test.s)
+		if (err == nil) != test.ok {
+			t.Errorf("parseVersion(%q) error = %v, want ok %v", test.s, err, test.ok)
+			continue
+		}
+		if got != test.want {
+			t.Errorf("parseVersion(%q) = %#x, want %#x", test.s, got, test.want)
+		}
+	}
+}
+
+func TestCheckProfileFile(t *testing.T) {
+	r := &Report{
+		Devices: []*DeviceReport{{
+			DeviceName: "GPU",
+			APIVersion: "1.3.250",
+			Extensions: []Extension{{Name: "VK_KHR_swapchain"}},
+			Limits:     DeviceLimits{MaxImageDimension2D: 16384, NonCoherentAtomSize: 64},
+		}},
+	}
+	dir, err := ioutil.TempDir("", "profile")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tests := []struct {
+		profile string
+		ok      bool
+	}{
+		{`{"minApiVersion": "1.1", "deviceExtensions": ["VK_KHR_swapchain"],
+			"minLimits": {"maxImageDimension2D": 8192, "nonCoherentAtomSize": 256}}`, true},
+		{`{"minLimits": {"nonCoherentAtomSize": 32}}`, false},
+		{`{"deviceExtensions": ["VK_KHR_ray_query"]}`, false},
+	}
+	for i, test := range tests {
+		path := filepath.Join(dir, "profile.json")
+		if err := ioutil.WriteFile(path, []byte(test.profile), 0644); err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		ok, err := r.CheckProfileFile(&buf, path)
+		if err != nil {
+			t.Errorf("profile %d: %s", i, err)
+			continue
+		}
+		if ok != test.ok {
+			t.Errorf("profile %d: got ok %v, want %v\n%s", i, ok, test.ok, buf.String())
+		}
+	}
+
+	path := filepath.Join(dir, "unknown.json")
+	if err := ioutil.WriteFile(path, []byte(`{"minLimits": {"maxWidgets": 1}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.CheckProfileFile(ioutil.Discard, path); err == nil {
+		t.Error("a profile with an unknown limit is accepted")
+	}
+}
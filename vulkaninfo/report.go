@@ -0,0 +1,564 @@
+package vulkaninfo
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// Report is a serializable snapshot of the Vulkan instance and its physical devices,
+// both the table output of PrintInfo and the JSON output are rendered from it.
+type Report struct {
+	PhysicalDevices    int             `json:"physicalDevices"`
+	InstanceExtensions []Extension     `json:"instanceExtensions"`
+	InstanceLayers     []Layer         `json:"instanceLayers"`
+	Devices            []*DeviceReport `json:"devices"`
+}
+
+type DeviceReport struct {
+	Index      int    `json:"index"`
+	DeviceName string `json:"deviceName"`
+	VendorID   uint32 `json:"vendorID"`
+	VendorName string `json:"vendorName"`
+	DeviceID   uint32 `json:"deviceID"`
+	DeviceType string `json:"deviceType"`
+	APIVersion string `json:"apiVersion"`
+	// DriverVersion is decoded the vendor-specific way, see driverVersion.
+	DriverVersion    string `json:"driverVersion"`
+	DriverVersionRaw uint32 `json:"driverVersionRaw"`
+
+	Extensions       []Extension      `json:"extensions"`
+	Layers           []Layer          `json:"layers"`
+	Limits           DeviceLimits     `json:"limits"`
+	SparseProperties SparseProperties `json:"sparseProperties"`
+	MemoryHeaps      []MemoryHeap     `json:"memoryHeaps"`
+	MemoryTypes      []MemoryType     `json:"memoryTypes"`
+	QueueFamilies    []QueueFamily    `json:"queueFamilies"`
+	Formats          []FormatFeatures `json:"formats"`
+	Surface          *SurfaceReport   `json:"surface,omitempty"`
+
+	// Vulkan 1.1+ features and properties, they are left out
+	// when the device or the instance only support Vulkan 1.0.
+	Vulkan11Features   *Vulkan11Features   `json:"vulkan11Features,omitempty"`
+	Vulkan12Features   *Vulkan12Features   `json:"vulkan12Features,omitempty"`
+	Vulkan13Features   *Vulkan13Features   `json:"vulkan13Features,omitempty"`
+	Subgroup           *SubgroupProperties `json:"subgroupProperties,omitempty"`
+	Driver             *DriverProperties   `json:"driverProperties,omitempty"`
+	DescriptorIndexing *DescriptorIndexing `json:"descriptorIndexing,omitempty"`
+}
+
+type MemoryHeap struct {
+	Size        uint64   `json:"size"`
+	DeviceLocal bool     `json:"deviceLocal"`
+	Flags       []string `json:"flags"`
+}
+
+type MemoryType struct {
+	HeapIndex     uint32   `json:"heapIndex"`
+	PropertyFlags []string `json:"propertyFlags"`
+}
+
+type QueueFamily struct {
+	QueueFlags                  []string `json:"queueFlags"`
+	QueueCount                  uint32   `json:"queueCount"`
+	TimestampValidBits          uint32   `json:"timestampValidBits"`
+	MinImageTransferGranularity Extent3D `json:"minImageTransferGranularity"`
+	// SupportsPresent is set only when there is a surface to check against.
+	SupportsPresent *bool `json:"supportsPresent,omitempty"`
+}
+
+// FormatFeatures holds the features of a core format,
+// formats without any feature are not reported.
+type FormatFeatures struct {
+	Format                string   `json:"format"`
+	LinearTilingFeatures  []string `json:"linearTilingFeatures"`
+	OptimalTilingFeatures []string `json:"optimalTilingFeatures"`
+	BufferFeatures        []string `json:"bufferFeatures"`
+}
+
+type SurfaceFormat struct {
+	Format     string `json:"format"`
+	ColorSpace string `json:"colorSpace"`
+}
+
+type Extent2D struct {
+	Width  uint32 `json:"width"`
+	Height uint32 `json:"height"`
+}
+
+type Extent3D struct {
+	Width  uint32 `json:"width"`
+	Height uint32 `json:"height"`
+	Depth  uint32 `json:"depth"`
+}
+
+type SurfaceReport struct {
+	MinImageCount           uint32   `json:"minImageCount"`
+	MaxImageCount           uint32   `json:"maxImageCount"`
+	CurrentExtent           Extent2D `json:"currentExtent"`
+	MinImageExtent          Extent2D `json:"minImageExtent"`
+	MaxImageExtent          Extent2D `json:"maxImageExtent"`
+	MaxImageArrayLayers     uint32   `json:"maxImageArrayLayers"`
+	SupportedTransforms     []string `json:"supportedTransforms"`
+	CurrentTransform        string   `json:"currentTransform"`
+	SupportedCompositeAlpha []string `json:"supportedCompositeAlpha"`
+	SupportedUsageFlags     []string `json:"supportedUsageFlags"`
+
+	Formats      []SurfaceFormat `json:"formats"`
+	PresentModes []string        `json:"presentModes"`
+}
+
+// NewReport collects the instance info and the info of every physical device,
+// or only the device with the given index unless it is AllDevices.
+func NewReport(v *VulkanDeviceInfo, device int) (*Report, error) {
+	if device != AllDevices && (device < 0 || device >= len(v.gpuDevices)) {
+		err := fmt.Errorf("device index %d is out of range, found %d physical devices", device, len(v.gpuDevices))
+		return nil, err
+	}
+	r := &Report{
+		PhysicalDevices: len(v.gpuDevices),
+	}
+	var err error
+	if r.InstanceExtensions, err = InstanceExtensions(); err != nil {
+		return nil, err
+	}
+	if r.InstanceLayers, err = InstanceLayers(); err != nil {
+		return nil, err
+	}
+	for i := range v.gpuDevices {
+		if device != AllDevices && device != i {
+			continue
+		}
+		d, err := newDeviceReport(v, i)
+		if err != nil {
+			return nil, err
+		}
+		r.Devices = append(r.Devices, d)
+	}
+	return r, nil
+}
+
+// WriteJSON serializes the report as indented JSON.
+func WriteJSON(w io.Writer, r *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(r)
+}
+
+func newDeviceReport(v *VulkanDeviceInfo, idx int) (*DeviceReport, error) {
+	gpu := v.gpuDevices[idx]
+
+	var gpuProperties vk.PhysicalDeviceProperties
+	vk.GetPhysicalDeviceProperties(gpu, &gpuProperties)
+	gpuProperties.Deref()
+	gpuProperties.Limits.Deref()
+	gpuProperties.SparseProperties.Deref()
+
+	d := &DeviceReport{
+		Index:      idx,
+		DeviceName: vk.ToString(gpuProperties.DeviceName[:]),
+		VendorID:   gpuProperties.VendorID,
+		VendorName: vendorName(gpuProperties.VendorID),
+		DeviceID:   gpuProperties.DeviceID,
+		DeviceType: physicalDeviceType(gpuProperties.DeviceType),
+		APIVersion: vk.Version(gpuProperties.ApiVersion).String(),
+		DriverVersion: driverVersion(gpuProperties.VendorID, gpuProperties.DriverVersion,
+			runtime.GOOS == "windows"),
+		DriverVersionRaw: gpuProperties.DriverVersion,
+		Limits:           newDeviceLimits(gpuProperties.Limits),
+		SparseProperties: newSparseProperties(gpuProperties.SparseProperties),
+	}
+	var err error
+	if d.Extensions, err = DeviceExtensions(gpu); err != nil {
+		return nil, err
+	}
+	if d.Layers, err = DeviceLayers(gpu); err != nil {
+		return nil, err
+	}
+	v.addFeatures2(d, gpu, gpuProperties.ApiVersion)
+
+	var memProperties vk.PhysicalDeviceMemoryProperties
+	vk.GetPhysicalDeviceMemoryProperties(gpu, &memProperties)
+	memProperties.Deref()
+	for i := uint32(0); i < memProperties.MemoryHeapCount; i++ {
+		heap := memProperties.MemoryHeaps[i]
+		heap.Deref()
+		d.MemoryHeaps = append(d.MemoryHeaps, MemoryHeap{
+			Size:        uint64(heap.Size),
+			DeviceLocal: heap.Flags&vk.MemoryHeapFlags(vk.MemoryHeapDeviceLocalBit) != 0,
+			Flags:       flagNames(uint32(heap.Flags), memoryHeapFlagNames),
+		})
+	}
+	for i := uint32(0); i < memProperties.MemoryTypeCount; i++ {
+		memType := memProperties.MemoryTypes[i]
+		memType.Deref()
+		d.MemoryTypes = append(d.MemoryTypes, MemoryType{
+			HeapIndex:     memType.HeapIndex,
+			PropertyFlags: flagNames(uint32(memType.PropertyFlags), memoryPropertyFlagNames),
+		})
+	}
+
+	for i, family := range getQueueFamilies(gpu) {
+		family.MinImageTransferGranularity.Deref()
+		granularity := family.MinImageTransferGranularity
+		queueFamily := QueueFamily{
+			QueueFlags:         flagNames(uint32(family.QueueFlags), queueFlagNames),
+			QueueCount:         family.QueueCount,
+			TimestampValidBits: family.TimestampValidBits,
+			MinImageTransferGranularity: Extent3D{
+				Width:  granularity.Width,
+				Height: granularity.Height,
+				Depth:  granularity.Depth,
+			},
+		}
+		if v.surface != vk.NullSurface {
+			var supported vk.Bool32
+			err = vk.Error(vk.GetPhysicalDeviceSurfaceSupport(gpu, uint32(i), v.surface, &supported))
+			if err != nil {
+				err = fmt.Errorf("vkGetPhysicalDeviceSurfaceSupport failed with %s", err)
+				return nil, err
+			}
+			supportsPresent := supported.B()
+			queueFamily.SupportsPresent = &supportsPresent
+		}
+		d.QueueFamilies = append(d.QueueFamilies, queueFamily)
+	}
+
+	for _, format := range coreFormats {
+		var props vk.FormatProperties
+		vk.GetPhysicalDeviceFormatProperties(gpu, format, &props)
+		props.Deref()
+		if props.LinearTilingFeatures|props.OptimalTilingFeatures|props.BufferFeatures == 0 {
+			continue
+		}
+		d.Formats = append(d.Formats, FormatFeatures{
+			Format:                formatName(format),
+			LinearTilingFeatures:  flagNames(uint32(props.LinearTilingFeatures), formatFeatureFlagNames),
+			OptimalTilingFeatures: flagNames(uint32(props.OptimalTilingFeatures), formatFeatureFlagNames),
+			BufferFeatures:        flagNames(uint32(props.BufferFeatures), formatFeatureFlagNames),
+		})
+	}
+
+	if v.surface != vk.NullSurface {
+		if d.Surface, err = newSurfaceReport(gpu, v.surface); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func newSurfaceReport(gpu vk.PhysicalDevice, surface vk.Surface) (*SurfaceReport, error) {
+	var surfaceCapabilities vk.SurfaceCapabilities
+	err := vk.Error(vk.GetPhysicalDeviceSurfaceCapabilities(gpu, surface, &surfaceCapabilities))
+	if err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceSurfaceCapabilities failed with %s", err)
+		return nil, err
+	}
+	surfaceCapabilities.Deref()
+	surfaceCapabilities.CurrentExtent.Deref()
+	surfaceCapabilities.MinImageExtent.Deref()
+	surfaceCapabilities.MaxImageExtent.Deref()
+	s := &SurfaceReport{
+		MinImageCount:       surfaceCapabilities.MinImageCount,
+		MaxImageCount:       surfaceCapabilities.MaxImageCount,
+		CurrentExtent:       newExtent(surfaceCapabilities.CurrentExtent),
+		MinImageExtent:      newExtent(surfaceCapabilities.MinImageExtent),
+		MaxImageExtent:      newExtent(surfaceCapabilities.MaxImageExtent),
+		MaxImageArrayLayers: surfaceCapabilities.MaxImageArrayLayers,
+		SupportedTransforms: flagNames(uint32(surfaceCapabilities.SupportedTransforms),
+			surfaceTransformFlagNames),
+		CurrentTransform: joinFlags(flagNames(uint32(surfaceCapabilities.CurrentTransform),
+			surfaceTransformFlagNames)),
+		SupportedCompositeAlpha: flagNames(uint32(surfaceCapabilities.SupportedCompositeAlpha),
+			compositeAlphaFlagNames),
+		SupportedUsageFlags: flagNames(uint32(surfaceCapabilities.SupportedUsageFlags),
+			imageUsageFlagNames),
+	}
+
+	var formatCount uint32
+	err = vk.Error(vk.GetPhysicalDeviceSurfaceFormats(gpu, surface, &formatCount, nil))
+	if err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceSurfaceFormats failed with %s", err)
+		return nil, err
+	}
+	formats := make([]vk.SurfaceFormat, formatCount)
+	err = vk.Error(vk.GetPhysicalDeviceSurfaceFormats(gpu, surface, &formatCount, formats))
+	if err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceSurfaceFormats failed with %s", err)
+		return nil, err
+	}
+	for _, format := range formats {
+		format.Deref()
+		s.Formats = append(s.Formats, SurfaceFormat{
+			Format:     formatName(format.Format),
+			ColorSpace: colorSpaceName(format.ColorSpace),
+		})
+	}
+
+	var presentModeCount uint32
+	err = vk.Error(vk.GetPhysicalDeviceSurfacePresentModes(gpu, surface, &presentModeCount, nil))
+	if err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceSurfacePresentModes failed with %s", err)
+		return nil, err
+	}
+	presentModes := make([]vk.PresentMode, presentModeCount)
+	err = vk.Error(vk.GetPhysicalDeviceSurfacePresentModes(gpu, surface, &presentModeCount, presentModes))
+	if err != nil {
+		err = fmt.Errorf("vkGetPhysicalDeviceSurfacePresentModes failed with %s", err)
+		return nil, err
+	}
+	for _, mode := range presentModes {
+		s.PresentModes = append(s.PresentModes, presentModeName(mode))
+	}
+	return s, nil
+}
+
+func newExtent(e vk.Extent2D) Extent2D {
+	return Extent2D{
+		Width:  e.Width,
+		Height: e.Height,
+	}
+}
+
+// DeviceLimits mirrors vk.PhysicalDeviceLimits using plain Go types.
+type DeviceLimits struct {
+	MaxImageDimension1D                             uint32     `json:"maxImageDimension1D"`
+	MaxImageDimension2D                             uint32     `json:"maxImageDimension2D"`
+	MaxImageDimension3D                             uint32     `json:"maxImageDimension3D"`
+	MaxImageDimensionCube                           uint32     `json:"maxImageDimensionCube"`
+	MaxImageArrayLayers                             uint32     `json:"maxImageArrayLayers"`
+	MaxTexelBufferElements                          uint32     `json:"maxTexelBufferElements"`
+	MaxUniformBufferRange                           uint32     `json:"maxUniformBufferRange"`
+	MaxStorageBufferRange                           uint32     `json:"maxStorageBufferRange"`
+	MaxPushConstantsSize                            uint32     `json:"maxPushConstantsSize"`
+	MaxMemoryAllocationCount                        uint32     `json:"maxMemoryAllocationCount"`
+	MaxSamplerAllocationCount                       uint32     `json:"maxSamplerAllocationCount"`
+	BufferImageGranularity                          uint64     `json:"bufferImageGranularity"`
+	SparseAddressSpaceSize                          uint64     `json:"sparseAddressSpaceSize"`
+	MaxBoundDescriptorSets                          uint32     `json:"maxBoundDescriptorSets"`
+	MaxPerStageDescriptorSamplers                   uint32     `json:"maxPerStageDescriptorSamplers"`
+	MaxPerStageDescriptorUniformBuffers             uint32     `json:"maxPerStageDescriptorUniformBuffers"`
+	MaxPerStageDescriptorStorageBuffers             uint32     `json:"maxPerStageDescriptorStorageBuffers"`
+	MaxPerStageDescriptorSampledImages              uint32     `json:"maxPerStageDescriptorSampledImages"`
+	MaxPerStageDescriptorStorageImages              uint32     `json:"maxPerStageDescriptorStorageImages"`
+	MaxPerStageDescriptorInputAttachments           uint32     `json:"maxPerStageDescriptorInputAttachments"`
+	MaxPerStageResources                            uint32     `json:"maxPerStageResources"`
+	MaxDescriptorSetSamplers                        uint32     `json:"maxDescriptorSetSamplers"`
+	MaxDescriptorSetUniformBuffers                  uint32     `json:"maxDescriptorSetUniformBuffers"`
+	MaxDescriptorSetUniformBuffersDynamic           uint32     `json:"maxDescriptorSetUniformBuffersDynamic"`
+	MaxDescriptorSetStorageBuffers                  uint32     `json:"maxDescriptorSetStorageBuffers"`
+	MaxDescriptorSetStorageBuffersDynamic           uint32     `json:"maxDescriptorSetStorageBuffersDynamic"`
+	MaxDescriptorSetSampledImages                   uint32     `json:"maxDescriptorSetSampledImages"`
+	MaxDescriptorSetStorageImages                   uint32     `json:"maxDescriptorSetStorageImages"`
+	MaxDescriptorSetInputAttachments                uint32     `json:"maxDescriptorSetInputAttachments"`
+	MaxVertexInputAttributes                        uint32     `json:"maxVertexInputAttributes"`
+	MaxVertexInputBindings                          uint32     `json:"maxVertexInputBindings"`
+	MaxVertexInputAttributeOffset                   uint32     `json:"maxVertexInputAttributeOffset"`
+	MaxVertexInputBindingStride                     uint32     `json:"maxVertexInputBindingStride"`
+	MaxVertexOutputComponents                       uint32     `json:"maxVertexOutputComponents"`
+	MaxTessellationGenerationLevel                  uint32     `json:"maxTessellationGenerationLevel"`
+	MaxTessellationPatchSize                        uint32     `json:"maxTessellationPatchSize"`
+	MaxTessellationControlPerVertexInputComponents  uint32     `json:"maxTessellationControlPerVertexInputComponents"`
+	MaxTessellationControlPerVertexOutputComponents uint32     `json:"maxTessellationControlPerVertexOutputComponents"`
+	MaxTessellationControlPerPatchOutputComponents  uint32     `json:"maxTessellationControlPerPatchOutputComponents"`
+	MaxTessellationControlTotalOutputComponents     uint32     `json:"maxTessellationControlTotalOutputComponents"`
+	MaxTessellationEvaluationInputComponents        uint32     `json:"maxTessellationEvaluationInputComponents"`
+	MaxTessellationEvaluationOutputComponents       uint32     `json:"maxTessellationEvaluationOutputComponents"`
+	MaxGeometryShaderInvocations                    uint32     `json:"maxGeometryShaderInvocations"`
+	MaxGeometryInputComponents                      uint32     `json:"maxGeometryInputComponents"`
+	MaxGeometryOutputComponents                     uint32     `json:"maxGeometryOutputComponents"`
+	MaxGeometryOutputVertices                       uint32     `json:"maxGeometryOutputVertices"`
+	MaxGeometryTotalOutputComponents                uint32     `json:"maxGeometryTotalOutputComponents"`
+	MaxFragmentInputComponents                      uint32     `json:"maxFragmentInputComponents"`
+	MaxFragmentOutputAttachments                    uint32     `json:"maxFragmentOutputAttachments"`
+	MaxFragmentDualSrcAttachments                   uint32     `json:"maxFragmentDualSrcAttachments"`
+	MaxFragmentCombinedOutputResources              uint32     `json:"maxFragmentCombinedOutputResources"`
+	MaxComputeSharedMemorySize                      uint32     `json:"maxComputeSharedMemorySize"`
+	MaxComputeWorkGroupCount                        [3]uint32  `json:"maxComputeWorkGroupCount"`
+	MaxComputeWorkGroupInvocations                  uint32     `json:"maxComputeWorkGroupInvocations"`
+	MaxComputeWorkGroupSize                         [3]uint32  `json:"maxComputeWorkGroupSize"`
+	SubPixelPrecisionBits                           uint32     `json:"subPixelPrecisionBits"`
+	SubTexelPrecisionBits                           uint32     `json:"subTexelPrecisionBits"`
+	MipmapPrecisionBits                             uint32     `json:"mipmapPrecisionBits"`
+	MaxDrawIndexedIndexValue                        uint32     `json:"maxDrawIndexedIndexValue"`
+	MaxDrawIndirectCount                            uint32     `json:"maxDrawIndirectCount"`
+	MaxSamplerLodBias                               float32    `json:"maxSamplerLodBias"`
+	MaxSamplerAnisotropy                            float32    `json:"maxSamplerAnisotropy"`
+	MaxViewports                                    uint32     `json:"maxViewports"`
+	MaxViewportDimensions                           [2]uint32  `json:"maxViewportDimensions"`
+	ViewportBoundsRange                             [2]float32 `json:"viewportBoundsRange"`
+	ViewportSubPixelBits                            uint32     `json:"viewportSubPixelBits"`
+	MinMemoryMapAlignment                           uint64     `json:"minMemoryMapAlignment"`
+	MinTexelBufferOffsetAlignment                   uint64     `json:"minTexelBufferOffsetAlignment"`
+	MinUniformBufferOffsetAlignment                 uint64     `json:"minUniformBufferOffsetAlignment"`
+	MinStorageBufferOffsetAlignment                 uint64     `json:"minStorageBufferOffsetAlignment"`
+	MinTexelOffset                                  int32      `json:"minTexelOffset"`
+	MaxTexelOffset                                  uint32     `json:"maxTexelOffset"`
+	MinTexelGatherOffset                            int32      `json:"minTexelGatherOffset"`
+	MaxTexelGatherOffset                            uint32     `json:"maxTexelGatherOffset"`
+	MinInterpolationOffset                          float32    `json:"minInterpolationOffset"`
+	MaxInterpolationOffset                          float32    `json:"maxInterpolationOffset"`
+	SubPixelInterpolationOffsetBits                 uint32     `json:"subPixelInterpolationOffsetBits"`
+	MaxFramebufferWidth                             uint32     `json:"maxFramebufferWidth"`
+	MaxFramebufferHeight                            uint32     `json:"maxFramebufferHeight"`
+	MaxFramebufferLayers                            uint32     `json:"maxFramebufferLayers"`
+	FramebufferColorSampleCounts                    uint32     `json:"framebufferColorSampleCounts"`
+	FramebufferDepthSampleCounts                    uint32     `json:"framebufferDepthSampleCounts"`
+	FramebufferStencilSampleCounts                  uint32     `json:"framebufferStencilSampleCounts"`
+	FramebufferNoAttachmentsSampleCounts            uint32     `json:"framebufferNoAttachmentsSampleCounts"`
+	MaxColorAttachments                             uint32     `json:"maxColorAttachments"`
+	SampledImageColorSampleCounts                   uint32     `json:"sampledImageColorSampleCounts"`
+	SampledImageIntegerSampleCounts                 uint32     `json:"sampledImageIntegerSampleCounts"`
+	SampledImageDepthSampleCounts                   uint32     `json:"sampledImageDepthSampleCounts"`
+	SampledImageStencilSampleCounts                 uint32     `json:"sampledImageStencilSampleCounts"`
+	StorageImageSampleCounts                        uint32     `json:"storageImageSampleCounts"`
+	MaxSampleMaskWords                              uint32     `json:"maxSampleMaskWords"`
+	TimestampComputeAndGraphics                     bool       `json:"timestampComputeAndGraphics"`
+	TimestampPeriod                                 float32    `json:"timestampPeriod"`
+	MaxClipDistances                                uint32     `json:"maxClipDistances"`
+	MaxCullDistances                                uint32     `json:"maxCullDistances"`
+	MaxCombinedClipAndCullDistances                 uint32     `json:"maxCombinedClipAndCullDistances"`
+	DiscreteQueuePriorities                         uint32     `json:"discreteQueuePriorities"`
+	PointSizeRange                                  [2]float32 `json:"pointSizeRange"`
+	LineWidthRange                                  [2]float32 `json:"lineWidthRange"`
+	PointSizeGranularity                            float32    `json:"pointSizeGranularity"`
+	LineWidthGranularity                            float32    `json:"lineWidthGranularity"`
+	StrictLines                                     bool       `json:"strictLines"`
+	StandardSampleLocations                         bool       `json:"standardSampleLocations"`
+	OptimalBufferCopyOffsetAlignment                uint64     `json:"optimalBufferCopyOffsetAlignment"`
+	OptimalBufferCopyRowPitchAlignment              uint64     `json:"optimalBufferCopyRowPitchAlignment"`
+	NonCoherentAtomSize                             uint64     `json:"nonCoherentAtomSize"`
+}
+
+func newDeviceLimits(l vk.PhysicalDeviceLimits) DeviceLimits {
+	return DeviceLimits{
+		MaxImageDimension1D:                             l.MaxImageDimension1D,
+		MaxImageDimension2D:                             l.MaxImageDimension2D,
+		MaxImageDimension3D:                             l.MaxImageDimension3D,
+		MaxImageDimensionCube:                           l.MaxImageDimensionCube,
+		MaxImageArrayLayers:                             l.MaxImageArrayLayers,
+		MaxTexelBufferElements:                          l.MaxTexelBufferElements,
+		MaxUniformBufferRange:                           l.MaxUniformBufferRange,
+		MaxStorageBufferRange:                           l.MaxStorageBufferRange,
+		MaxPushConstantsSize:                            l.MaxPushConstantsSize,
+		MaxMemoryAllocationCount:                        l.MaxMemoryAllocationCount,
+		MaxSamplerAllocationCount:                       l.MaxSamplerAllocationCount,
+		BufferImageGranularity:                          uint64(l.BufferImageGranularity),
+		SparseAddressSpaceSize:                          uint64(l.SparseAddressSpaceSize),
+		MaxBoundDescriptorSets:                          l.MaxBoundDescriptorSets,
+		MaxPerStageDescriptorSamplers:                   l.MaxPerStageDescriptorSamplers,
+		MaxPerStageDescriptorUniformBuffers:             l.MaxPerStageDescriptorUniformBuffers,
+		MaxPerStageDescriptorStorageBuffers:             l.MaxPerStageDescriptorStorageBuffers,
+		MaxPerStageDescriptorSampledImages:              l.MaxPerStageDescriptorSampledImages,
+		MaxPerStageDescriptorStorageImages:              l.MaxPerStageDescriptorStorageImages,
+		MaxPerStageDescriptorInputAttachments:           l.MaxPerStageDescriptorInputAttachments,
+		MaxPerStageResources:                            l.MaxPerStageResources,
+		MaxDescriptorSetSamplers:                        l.MaxDescriptorSetSamplers,
+		MaxDescriptorSetUniformBuffers:                  l.MaxDescriptorSetUniformBuffers,
+		MaxDescriptorSetUniformBuffersDynamic:           l.MaxDescriptorSetUniformBuffersDynamic,
+		MaxDescriptorSetStorageBuffers:                  l.MaxDescriptorSetStorageBuffers,
+		MaxDescriptorSetStorageBuffersDynamic:           l.MaxDescriptorSetStorageBuffersDynamic,
+		MaxDescriptorSetSampledImages:                   l.MaxDescriptorSetSampledImages,
+		MaxDescriptorSetStorageImages:                   l.MaxDescriptorSetStorageImages,
+		MaxDescriptorSetInputAttachments:                l.MaxDescriptorSetInputAttachments,
+		MaxVertexInputAttributes:                        l.MaxVertexInputAttributes,
+		MaxVertexInputBindings:                          l.MaxVertexInputBindings,
+		MaxVertexInputAttributeOffset:                   l.MaxVertexInputAttributeOffset,
+		MaxVertexInputBindingStride:                     l.MaxVertexInputBindingStride,
+		MaxVertexOutputComponents:                       l.MaxVertexOutputComponents,
+		MaxTessellationGenerationLevel:                  l.MaxTessellationGenerationLevel,
+		MaxTessellationPatchSize:                        l.MaxTessellationPatchSize,
+		MaxTessellationControlPerVertexInputComponents:  l.MaxTessellationControlPerVertexInputComponents,
+		MaxTessellationControlPerVertexOutputComponents: l.MaxTessellationControlPerVertexOutputComponents,
+		MaxTessellationControlPerPatchOutputComponents:  l.MaxTessellationControlPerPatchOutputComponents,
+		MaxTessellationControlTotalOutputComponents:     l.MaxTessellationControlTotalOutputComponents,
+		MaxTessellationEvaluationInputComponents:        l.MaxTessellationEvaluationInputComponents,
+		MaxTessellationEvaluationOutputComponents:       l.MaxTessellationEvaluationOutputComponents,
+		MaxGeometryShaderInvocations:                    l.MaxGeometryShaderInvocations,
+		MaxGeometryInputComponents:                      l.MaxGeometryInputComponents,
+		MaxGeometryOutputComponents:                     l.MaxGeometryOutputComponents,
+		MaxGeometryOutputVertices:                       l.MaxGeometryOutputVertices,
+		MaxGeometryTotalOutputComponents:                l.MaxGeometryTotalOutputComponents,
+		MaxFragmentInputComponents:                      l.MaxFragmentInputComponents,
+		MaxFragmentOutputAttachments:                    l.MaxFragmentOutputAttachments,
+		MaxFragmentDualSrcAttachments:                   l.MaxFragmentDualSrcAttachments,
+		MaxFragmentCombinedOutputResources:              l.MaxFragmentCombinedOutputResources,
+		MaxComputeSharedMemorySize:                      l.MaxComputeSharedMemorySize,
+		MaxComputeWorkGroupCount:                        l.MaxComputeWorkGroupCount,
+		MaxComputeWorkGroupInvocations:                  l.MaxComputeWorkGroupInvocations,
+		MaxComputeWorkGroupSize:                         l.MaxComputeWorkGroupSize,
+		SubPixelPrecisionBits:                           l.SubPixelPrecisionBits,
+		SubTexelPrecisionBits:                           l.SubTexelPrecisionBits,
+		MipmapPrecisionBits:                             l.MipmapPrecisionBits,
+		MaxDrawIndexedIndexValue:                        l.MaxDrawIndexedIndexValue,
+		MaxDrawIndirectCount:                            l.MaxDrawIndirectCount,
+		MaxSamplerLodBias:                               l.MaxSamplerLodBias,
+		MaxSamplerAnisotropy:                            l.MaxSamplerAnisotropy,
+		MaxViewports:                                    l.MaxViewports,
+		MaxViewportDimensions:                           l.MaxViewportDimensions,
+		ViewportBoundsRange:                             l.ViewportBoundsRange,
+		ViewportSubPixelBits:                            l.ViewportSubPixelBits,
+		MinMemoryMapAlignment:                           uint64(l.MinMemoryMapAlignment),
+		MinTexelBufferOffsetAlignment:                   uint64(l.MinTexelBufferOffsetAlignment),
+		MinUniformBufferOffsetAlignment:                 uint64(l.MinUniformBufferOffsetAlignment),
+		MinStorageBufferOffsetAlignment:                 uint64(l.MinStorageBufferOffsetAlignment),
+		MinTexelOffset:                                  l.MinTexelOffset,
+		MaxTexelOffset:                                  l.MaxTexelOffset,
+		MinTexelGatherOffset:                            l.MinTexelGatherOffset,
+		MaxTexelGatherOffset:                            l.MaxTexelGatherOffset,
+		MinInterpolationOffset:                          l.MinInterpolationOffset,
+		MaxInterpolationOffset:                          l.MaxInterpolationOffset,
+		SubPixelInterpolationOffsetBits:                 l.SubPixelInterpolationOffsetBits,
+		MaxFramebufferWidth:                             l.MaxFramebufferWidth,
+		MaxFramebufferHeight:                            l.MaxFramebufferHeight,
+		MaxFramebufferLayers:                            l.MaxFramebufferLayers,
+		FramebufferColorSampleCounts:                    uint32(l.FramebufferColorSampleCounts),
+		FramebufferDepthSampleCounts:                    uint32(l.FramebufferDepthSampleCounts),
+		FramebufferStencilSampleCounts:                  uint32(l.FramebufferStencilSampleCounts),
+		FramebufferNoAttachmentsSampleCounts:            uint32(l.FramebufferNoAttachmentsSampleCounts),
+		MaxColorAttachments:                             l.MaxColorAttachments,
+		SampledImageColorSampleCounts:                   uint32(l.SampledImageColorSampleCounts),
+		SampledImageIntegerSampleCounts:                 uint32(l.SampledImageIntegerSampleCounts),
+		SampledImageDepthSampleCounts:                   uint32(l.SampledImageDepthSampleCounts),
+		SampledImageStencilSampleCounts:                 uint32(l.SampledImageStencilSampleCounts),
+		StorageImageSampleCounts:                        uint32(l.StorageImageSampleCounts),
+		MaxSampleMaskWords:                              l.MaxSampleMaskWords,
+		TimestampComputeAndGraphics:                     l.TimestampComputeAndGraphics.B(),
+		TimestampPeriod:                                 l.TimestampPeriod,
+		MaxClipDistances:                                l.MaxClipDistances,
+		MaxCullDistances:                                l.MaxCullDistances,
+		MaxCombinedClipAndCullDistances:                 l.MaxCombinedClipAndCullDistances,
+		DiscreteQueuePriorities:                         l.DiscreteQueuePriorities,
+		PointSizeRange:                                  l.PointSizeRange,
+		LineWidthRange:                                  l.LineWidthRange,
+		PointSizeGranularity:                            l.PointSizeGranularity,
+		LineWidthGranularity:                            l.LineWidthGranularity,
+		StrictLines:                                     l.StrictLines.B(),
+		StandardSampleLocations:                         l.StandardSampleLocations.B(),
+		OptimalBufferCopyOffsetAlignment:                uint64(l.OptimalBufferCopyOffsetAlignment),
+		OptimalBufferCopyRowPitchAlignment:              uint64(l.OptimalBufferCopyRowPitchAlignment),
+		NonCoherentAtomSize:                             uint64(l.NonCoherentAtomSize),
+	}
+}
+
+// SparseProperties mirrors vk.PhysicalDeviceSparseProperties using plain Go types.
+type SparseProperties struct {
+	ResidencyStandard2DBlockShape            bool `json:"residencyStandard2DBlockShape"`
+	ResidencyStandard2DMultisampleBlockShape bool `json:"residencyStandard2DMultisampleBlockShape"`
+	ResidencyStandard3DBlockShape            bool `json:"residencyStandard3DBlockShape"`
+	ResidencyAlignedMipSize                  bool `json:"residencyAlignedMipSize"`
+	ResidencyNonResidentStrict               bool `json:"residencyNonResidentStrict"`
+}
+
+func newSparseProperties(p vk.PhysicalDeviceSparseProperties) SparseProperties {
+	return SparseProperties{
+		ResidencyStandard2DBlockShape:            p.ResidencyStandard2DBlockShape.B(),
+		ResidencyStandard2DMultisampleBlockShape: p.ResidencyStandard2DMultisampleBlockShape.B(),
+		ResidencyStandard3DBlockShape:            p.ResidencyStandard3DBlockShape.B(),
+		ResidencyAlignedMipSize:                  p.ResidencyAlignedMipSize.B(),
+		ResidencyNonResidentStrict:               p.ResidencyNonResidentStrict.B(),
+	}
+}
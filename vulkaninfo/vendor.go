@@ -0,0 +1,71 @@
+package vulkaninfo
+
+import (
+	"fmt"
+
+	vk "github.com/vulkan-go/vulkan"
+)
+
+// PCI vendor IDs, and the Khronos vendor IDs of vendors without one.
+const (
+	vendorAMD         = 0x1002
+	vendorImgTec      = 0x1010
+	vendorApple       = 0x106B
+	vendorNVIDIA      = 0x10DE
+	vendorARM         = 0x13B5
+	vendorMicrosoft   = 0x1414
+	vendorBroadcom    = 0x14E4
+	vendorGoogle      = 0x1AE0
+	vendorQualcomm    = 0x5143
+	vendorIntel       = 0x8086
+	vendorVivante     = 0x10001
+	vendorVeriSilicon = 0x10002
+	vendorKazan       = 0x10003
+	vendorCodeplay    = 0x10004
+	vendorMesa        = 0x10005
+	vendorPoCL        = 0x10006
+	vendorMobileye    = 0x10007
+)
+
+var vendorNames = map[uint32]string{
+	vendorAMD:         "AMD",
+	vendorImgTec:      "ImgTec",
+	vendorApple:       "Apple",
+	vendorNVIDIA:      "NVIDIA",
+	vendorARM:         "ARM",
+	vendorMicrosoft:   "Microsoft",
+	vendorBroadcom:    "Broadcom",
+	vendorGoogle:      "Google",
+	vendorQualcomm:    "Qualcomm",
+	vendorIntel:       "Intel",
+	vendorVivante:     "Vivante",
+	vendorVeriSilicon: "VeriSilicon",
+	vendorKazan:       "Kazan",
+	vendorCodeplay:    "Codeplay",
+	vendorMesa:        "Mesa",
+	vendorPoCL:        "PoCL",
+	vendorMobileye:    "Mobileye",
+}
+
+// vendorName returns the name of the vendor, or its ID in hex if it is not known.
+func vendorName(vendorID uint32) string {
+	if name, ok := vendorNames[vendorID]; ok {
+		return name
+	}
+	return fmt.Sprintf("0x%x", vendorID)
+}
+
+// driverVersion decodes the driver version the way the vendor packs it,
+// drivers that follow the Vulkan convention are decoded as major.minor.patch.
+func driverVersion(vendorID, version uint32, windows bool) string {
+	switch {
+	case vendorID == vendorNVIDIA:
+		// 10 bits major, 8 bits minor, 8 bits secondary, 6 bits tertiary
+		return fmt.Sprintf("%d.%d.%d.%d",
+			version>>22, version>>14&0xff, version>>6&0xff, version&0x3f)
+	case vendorID == vendorIntel && windows:
+		// 18 bits major, 14 bits minor
+		return fmt.Sprintf("%d.%d", version>>14, version&0x3fff)
+	}
+	return vk.Version(version).String()
+}
@@ -0,0 +1,50 @@
+package vulkaninfo
+
+import "testing"
+
+func TestDriverVersion(t *testing.T) {
+	tests := []struct {
+		vendorID uint32
+		version  uint32
+		windows  bool
+		want     string
+	}{
+		// NVIDIA 535.104.5.0
+		{vendorNVIDIA, 535<<22 | 104<<14 | 5<<6, false, "535.104.5.0"},
+		{vendorNVIDIA, 2240053248, true, "534.18.0.0"},
+		// Intel 101.4255 on Windows, Mesa packs the Vulkan way on Linux
+		{vendorIntel, 101<<14 | 4255, true, "101.4255"},
+		{vendorIntel, 1642550, true, "100.4150"},
+		{vendorIntel, 23<<22 | 1<<12 | 6, false, "23.1.6"},
+		{vendorAMD, 2<<22 | 0<<12 | 279, true, "2.0.279"},
+		{vendorMesa, 1, false, "0.0.1"},
+	}
+	for _, tt := range tests {
+		if got := driverVersion(tt.vendorID, tt.version, tt.windows); got != tt.want {
+			t.Errorf("driverVersion(0x%x, %d, %v) = %s, want %s",
+				tt.vendorID, tt.version, tt.windows, got, tt.want)
+		}
+	}
+}
+
+func TestVendorName(t *testing.T) {
+	tests := []struct {
+		vendorID uint32
+		want     string
+	}{
+		{0x1002, "AMD"},
+		{0x10de, "NVIDIA"},
+		{0x8086, "Intel"},
+		{0x13b5, "ARM"},
+		{0x5143, "Qualcomm"},
+		{0x1010, "ImgTec"},
+		{0x106b, "Apple"},
+		{0x10005, "Mesa"},
+		{0x1234, "0x1234"},
+	}
+	for _, tt := range tests {
+		if got := vendorName(tt.vendorID); got != tt.want {
+			t.Errorf("vendorName(0x%x) = %s, want %s", tt.vendorID, got, tt.want)
+		}
+	}
+}
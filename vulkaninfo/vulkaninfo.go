@@ -2,7 +2,12 @@ package vulkaninfo
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
 
+	"github.com/vulkan-go/demos/internal/cstr"
 	vk "github.com/vulkan-go/vulkan"
 	"github.com/xlab/tablewriter"
 )
@@ -13,13 +18,40 @@ type VulkanDeviceInfo struct {
 	instance vk.Instance
 	surface  vk.Surface
 	device   vk.Device
+
+	// apiVersion is the API version the instance was created with,
+	// properties2KHR is set when VK_KHR_get_physical_device_properties2 was enabled.
+	apiVersion     uint32
+	properties2KHR bool
 }
 
-func NewVulkanDevice(appInfo *vk.ApplicationInfo, window uintptr) (*VulkanDeviceInfo, error) {
-	v := &VulkanDeviceInfo{}
+// NewVulkanDevice creates an instance with the given extensions and a logical device
+// on the first GPU. The createSurfaceFunc callback is optional, when it is provided
+// the surface it creates is used to report surface capabilities and present support,
+// so instanceExtensions must include the extensions it requires.
+// Set appInfo.ApiVersion to InstanceVersion() to report Vulkan 1.1+ features,
+// on 1.0 instances VK_KHR_get_physical_device_properties2 is enabled if available.
+func NewVulkanDevice(appInfo *vk.ApplicationInfo, instanceExtensions []string,
+	createSurfaceFunc func(interface{}) uintptr) (*VulkanDeviceInfo, error) {
+	v := &VulkanDeviceInfo{
+		apiVersion: vk.MakeVersion(1, 0, 0),
+	}
+	if appInfo != nil && appInfo.ApiVersion > v.apiVersion {
+		v.apiVersion = appInfo.ApiVersion
+	}
 
 	// step 1: create a Vulkan instance.
-	var instanceExtensions []string
+	instanceExtensions = cstr.Strings(instanceExtensions)
+	if v.apiVersion < vk.MakeVersion(1, 1, 0) {
+		const properties2 = "VK_KHR_get_physical_device_properties2\x00"
+		v.properties2KHR = hasName(instanceExtensions, properties2)
+		available, err := InstanceExtensions()
+		if err == nil && !v.properties2KHR &&
+			hasName(extensionNames(available), strings.TrimSuffix(properties2, "\x00")) {
+			instanceExtensions = append(instanceExtensions, properties2)
+			v.properties2KHR = true
+		}
+	}
 	instanceCreateInfo := &vk.InstanceCreateInfo{
 		SType:                   vk.StructureTypeInstanceCreateInfo,
 		PApplicationInfo:        appInfo,
@@ -34,15 +66,29 @@ func NewVulkanDevice(appInfo *vk.ApplicationInfo, window uintptr) (*VulkanDevice
 		vk.InitInstance(v.instance)
 	}
 
+	if createSurfaceFunc != nil {
+		v.surface = vk.SurfaceFromPointer(createSurfaceFunc(v.instance))
+		if v.surface == vk.NullSurface {
+			vk.DestroyInstance(v.instance, nil)
+			err = fmt.Errorf("surface creation failed")
+			return nil, err
+		}
+	}
+
 	if v.gpuDevices, err = getPhysicalDevices(v.instance); err != nil {
 		v.gpuDevices = nil
+		if v.surface != vk.NullSurface {
+			vk.DestroySurface(v.instance, v.surface, nil)
+		}
 		vk.DestroyInstance(v.instance, nil)
 		return nil, err
 	}
 
-	// step 2: create a logical device from the first GPU available.
+	// step 2: create a logical device from the first GPU available,
+	// using its first graphics-capable queue family.
 	queueCreateInfos := []vk.DeviceQueueCreateInfo{{
 		SType:            vk.StructureTypeDeviceQueueCreateInfo,
+		QueueFamilyIndex: graphicsQueueFamily(v.gpuDevices[0]),
 		QueueCount:       1,
 		PQueuePriorities: []float32{1.0},
 	}}
@@ -60,7 +106,9 @@ func NewVulkanDevice(appInfo *vk.ApplicationInfo, window uintptr) (*VulkanDevice
 	err = vk.Error(vk.CreateDevice(v.gpuDevices[0], deviceCreateInfo, nil, &device))
 	if err != nil {
 		v.gpuDevices = nil
-		vk.DestroySurface(v.instance, v.surface, nil)
+		if v.surface != vk.NullSurface {
+			vk.DestroySurface(v.instance, v.surface, nil)
+		}
 		vk.DestroyInstance(v.instance, nil)
 		err = fmt.Errorf("vkCreateDevice failed with %s", err)
 		return nil, err
@@ -77,6 +125,9 @@ func (v *VulkanDeviceInfo) Destroy() {
 	}
 	v.gpuDevices = nil
 	vk.DestroyDevice(v.device, nil)
+	if v.surface != vk.NullSurface {
+		vk.DestroySurface(v.instance, v.surface, nil)
+	}
 	vk.DestroyInstance(v.instance, nil)
 }
 
@@ -100,146 +151,372 @@ func getPhysicalDevices(instance vk.Instance) ([]vk.PhysicalDevice, error) {
 	return gpuList, nil
 }
 
-func getInstanceLayers() (layerNames []string) {
+func getQueueFamilies(gpu vk.PhysicalDevice) []vk.QueueFamilyProperties {
+	var queueFamilyCount uint32
+	vk.GetPhysicalDeviceQueueFamilyProperties(gpu, &queueFamilyCount, nil)
+	queueFamilies := make([]vk.QueueFamilyProperties, queueFamilyCount)
+	vk.GetPhysicalDeviceQueueFamilyProperties(gpu, &queueFamilyCount, queueFamilies)
+	for i := range queueFamilies {
+		queueFamilies[i].Deref()
+	}
+	return queueFamilies
+}
+
+// graphicsQueueFamily returns the index of the first queue family with graphics support,
+// falling back to 0 which is always a valid family index.
+func graphicsQueueFamily(gpu vk.PhysicalDevice) uint32 {
+	for i, family := range getQueueFamilies(gpu) {
+		if family.QueueFlags&vk.QueueFlags(vk.QueueGraphicsBit) != 0 {
+			return uint32(i)
+		}
+	}
+	return 0
+}
+
+// PhysicalDevices returns the physical devices enumerated by the instance.
+func (v *VulkanDeviceInfo) PhysicalDevices() []vk.PhysicalDevice {
+	return v.gpuDevices
+}
+
+// Extension describes an instance or device extension.
+type Extension struct {
+	Name        string `json:"extensionName"`
+	SpecVersion uint32 `json:"specVersion"`
+}
+
+// Layer describes an instance or device layer, instance layers also
+// list the instance extensions they provide.
+type Layer struct {
+	Name                  string      `json:"layerName"`
+	SpecVersion           uint32      `json:"specVersion"` // the packed API version, see vk.Version
+	ImplementationVersion uint32      `json:"implementationVersion"`
+	Description           string      `json:"description"`
+	Extensions            []Extension `json:"extensions,omitempty"`
+}
+
+// InstanceLayers returns the layers available to instances along with their extensions.
+func InstanceLayers() ([]Layer, error) {
 	var instanceLayerLen uint32
-	err := vk.EnumerateInstanceLayerProperties(&instanceLayerLen, nil)
-	orPanic(err)
+	err := vk.Error(vk.EnumerateInstanceLayerProperties(&instanceLayerLen, nil))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateInstanceLayerProperties failed with %s", err)
+		return nil, err
+	}
 	instanceLayers := make([]vk.LayerProperties, instanceLayerLen)
-	err = vk.EnumerateInstanceLayerProperties(&instanceLayerLen, instanceLayers)
-	orPanic(err)
-	for _, layer := range instanceLayers {
-		layer.Deref()
-		layerNames = append(layerNames,
-			vk.ToString(layer.LayerName[:]))
+	err = vk.Error(vk.EnumerateInstanceLayerProperties(&instanceLayerLen, instanceLayers))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateInstanceLayerProperties failed with %s", err)
+		return nil, err
+	}
+	layers := newLayers(instanceLayers)
+	for i := range layers {
+		if layers[i].Extensions, err = InstanceLayerExtensions(layers[i].Name); err != nil {
+			return nil, err
+		}
 	}
-	return layerNames
+	return layers, nil
 }
 
-func getDeviceLayers(gpu vk.PhysicalDevice) (layerNames []string) {
+// DeviceLayers returns the layers available to devices created from the GPU.
+func DeviceLayers(gpu vk.PhysicalDevice) ([]Layer, error) {
 	var deviceLayerLen uint32
-	err := vk.EnumerateDeviceLayerProperties(gpu, &deviceLayerLen, nil)
-	orPanic(err)
+	err := vk.Error(vk.EnumerateDeviceLayerProperties(gpu, &deviceLayerLen, nil))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateDeviceLayerProperties failed with %s", err)
+		return nil, err
+	}
 	deviceLayers := make([]vk.LayerProperties, deviceLayerLen)
-	err = vk.EnumerateDeviceLayerProperties(gpu, &deviceLayerLen, deviceLayers)
-	orPanic(err)
-	for _, layer := range deviceLayers {
-		layer.Deref()
-		layerNames = append(layerNames,
-			vk.ToString(layer.LayerName[:]))
+	err = vk.Error(vk.EnumerateDeviceLayerProperties(gpu, &deviceLayerLen, deviceLayers))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateDeviceLayerProperties failed with %s", err)
+		return nil, err
 	}
-	return layerNames
+	return newLayers(deviceLayers), nil
+}
+
+// InstanceExtensions returns the extensions available to instances.
+func InstanceExtensions() ([]Extension, error) {
+	return InstanceLayerExtensions("")
 }
 
-func getInstanceExtensions() (extNames []string) {
+// InstanceLayerExtensions returns the instance extensions provided by the layer,
+// or by the implementation and the implicitly enabled layers if layerName is empty.
+func InstanceLayerExtensions(layerName string) ([]Extension, error) {
+	if len(layerName) > 0 {
+		layerName = cstr.String(layerName)
+	}
 	var instanceExtLen uint32
-	err := vk.EnumerateInstanceExtensionProperties("", &instanceExtLen, nil)
-	orPanic(err)
+	err := vk.Error(vk.EnumerateInstanceExtensionProperties(layerName, &instanceExtLen, nil))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateInstanceExtensionProperties failed with %s", err)
+		return nil, err
+	}
 	instanceExt := make([]vk.ExtensionProperties, instanceExtLen)
-	err = vk.EnumerateInstanceExtensionProperties("", &instanceExtLen, instanceExt)
-	orPanic(err)
-	for _, ext := range instanceExt {
-		ext.Deref()
-		extNames = append(extNames,
-			vk.ToString(ext.ExtensionName[:]))
+	err = vk.Error(vk.EnumerateInstanceExtensionProperties(layerName, &instanceExtLen, instanceExt))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateInstanceExtensionProperties failed with %s", err)
+		return nil, err
 	}
-	return extNames
+	return newExtensions(instanceExt), nil
 }
 
-func getDeviceExtensions(gpu vk.PhysicalDevice) (extNames []string) {
+// DeviceExtensions returns the extensions supported by the GPU.
+func DeviceExtensions(gpu vk.PhysicalDevice) ([]Extension, error) {
 	var deviceExtLen uint32
-	err := vk.EnumerateDeviceExtensionProperties(gpu, "", &deviceExtLen, nil)
-	orPanic(err)
+	err := vk.Error(vk.EnumerateDeviceExtensionProperties(gpu, "", &deviceExtLen, nil))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateDeviceExtensionProperties failed with %s", err)
+		return nil, err
+	}
 	deviceExt := make([]vk.ExtensionProperties, deviceExtLen)
-	err = vk.EnumerateDeviceExtensionProperties(gpu, "", &deviceExtLen, deviceExt)
-	orPanic(err)
-	for _, ext := range deviceExt {
+	err = vk.Error(vk.EnumerateDeviceExtensionProperties(gpu, "", &deviceExtLen, deviceExt))
+	if err != nil {
+		err = fmt.Errorf("vkEnumerateDeviceExtensionProperties failed with %s", err)
+		return nil, err
+	}
+	return newExtensions(deviceExt), nil
+}
+
+func newExtensions(props []vk.ExtensionProperties) []Extension {
+	extensions := make([]Extension, 0, len(props))
+	for _, ext := range props {
 		ext.Deref()
-		extNames = append(extNames,
-			vk.ToString(ext.ExtensionName[:]))
+		extensions = append(extensions, Extension{
+			Name:        vk.ToString(ext.ExtensionName[:]),
+			SpecVersion: ext.SpecVersion,
+		})
 	}
-	return extNames
+	return extensions
 }
 
-func PrintInfo(v *VulkanDeviceInfo) {
-	var gpuProperties vk.PhysicalDeviceProperties
-	vk.GetPhysicalDeviceProperties(v.gpuDevices[0], &gpuProperties)
-	gpuProperties.Deref()
+func newLayers(props []vk.LayerProperties) []Layer {
+	layers := make([]Layer, 0, len(props))
+	for _, layer := range props {
+		layer.Deref()
+		layers = append(layers, Layer{
+			Name:                  vk.ToString(layer.LayerName[:]),
+			SpecVersion:           layer.SpecVersion,
+			ImplementationVersion: layer.ImplementationVersion,
+			Description:           vk.ToString(layer.Description[:]),
+		})
+	}
+	return layers
+}
 
+// extensionNames returns just the names of the extensions.
+func extensionNames(extensions []Extension) []string {
+	names := make([]string, 0, len(extensions))
+	for _, ext := range extensions {
+		names = append(names, ext.Name)
+	}
+	return names
+}
+
+// AllDevices makes PrintInfo report every physical device found on the system.
+const AllDevices = -1
+
+// DeviceCount returns the number of physical devices enumerated by the instance.
+func (v *VulkanDeviceInfo) DeviceCount() int {
+	return len(v.gpuDevices)
+}
+
+// PrintInfo prints instance-level info followed by a section per physical device,
+// or only the device with the given index unless it is AllDevices. It panics on errors,
+// use FprintInfo to handle them.
+func PrintInfo(v *VulkanDeviceInfo, device int) {
+	orPanic(FprintInfo(os.Stdout, v, device))
+}
+
+// FprintInfo is like PrintInfo but writes to w and returns errors.
+func FprintInfo(w io.Writer, v *VulkanDeviceInfo, device int) error {
+	r, err := NewReport(v, device)
+	if err != nil {
+		return err
+	}
+	return FprintReport(w, r)
+}
+
+// PrintReport renders the report as a table to stdout.
+func PrintReport(r *Report) {
+	orPanic(FprintReport(os.Stdout, r))
+}
+
+// FprintReport renders the report as a table to w.
+func FprintReport(w io.Writer, r *Report) error {
 	table := tablewriter.CreateTable()
 	table.UTF8Box()
 	table.AddTitle("VULKAN PROPERTIES AND SURFACE CAPABILITES")
-	table.AddRow("Physical Device Name", vk.ToString(gpuProperties.DeviceName[:]))
-	table.AddRow("Physical Device Vendor", fmt.Sprintf("%x", gpuProperties.VendorID))
-	if gpuProperties.DeviceType != vk.PhysicalDeviceTypeOther {
-		table.AddRow("Physical Device Type", physicalDeviceType(gpuProperties.DeviceType))
+	table.AddRow("Physical GPUs", r.PhysicalDevices)
+	table.AddSeparator()
+
+	table.AddRow("INSTANCE EXTENSIONS", "")
+	addExtensionRows(table, r.InstanceExtensions)
+
+	if len(r.InstanceLayers) > 0 {
+		table.AddSeparator()
+		table.AddRow("INSTANCE LAYERS")
+		addLayerRows(table, r.InstanceLayers)
 	}
-	table.AddRow("Physical GPUs", len(v.gpuDevices))
-	table.AddRow("API Version", vk.Version(gpuProperties.ApiVersion))
-	table.AddRow("API Version Supported", vk.Version(gpuProperties.ApiVersion))
-	table.AddRow("Driver Version", vk.Version(gpuProperties.DriverVersion))
 
-	if v.surface != vk.NullSurface {
-		var surfaceCapabilities vk.SurfaceCapabilities
-		vk.GetPhysicalDeviceSurfaceCapabilities(v.gpuDevices[0], v.surface, &surfaceCapabilities)
-		surfaceCapabilities.Deref()
-		surfaceCapabilities.CurrentExtent.Deref()
-		surfaceCapabilities.MinImageExtent.Deref()
-		surfaceCapabilities.MaxImageExtent.Deref()
+	for _, d := range r.Devices {
+		table.AddSeparator()
+		printDeviceReport(table, d)
+	}
+
+	_, err := fmt.Fprintln(w, "\n\n"+table.Render())
+	return err
+}
 
+func printDeviceReport(table *tablewriter.Table, d *DeviceReport) {
+	table.AddRow(fmt.Sprintf("DEVICE #%d", d.Index), "")
+	table.AddRow("Physical Device Name", d.DeviceName)
+	table.AddRow("Physical Device Vendor", fmt.Sprintf("%s (%x)", d.VendorName, d.VendorID))
+	if d.DeviceType != physicalDeviceType(vk.PhysicalDeviceTypeOther) {
+		table.AddRow("Physical Device Type", d.DeviceType)
+	}
+	table.AddRow("API Version", d.APIVersion)
+	table.AddRow("API Version Supported", d.APIVersion)
+	table.AddRow("Driver Version", d.DriverVersion)
+
+	if s := d.Surface; s != nil {
 		table.AddSeparator()
 		table.AddRow("Image count", fmt.Sprintf("%d - %d",
-			surfaceCapabilities.MinImageCount, surfaceCapabilities.MaxImageCount))
+			s.MinImageCount, s.MaxImageCount))
 		table.AddRow("Array layers", fmt.Sprintf("%d",
-			surfaceCapabilities.MaxImageArrayLayers))
+			s.MaxImageArrayLayers))
 		table.AddRow("Image size (current)", fmt.Sprintf("%dx%d",
-			surfaceCapabilities.CurrentExtent.Width, surfaceCapabilities.CurrentExtent.Height))
+			s.CurrentExtent.Width, s.CurrentExtent.Height))
 		table.AddRow("Image size (extent)", fmt.Sprintf("%dx%d - %dx%d",
-			surfaceCapabilities.MinImageExtent.Width, surfaceCapabilities.MinImageExtent.Height,
-			surfaceCapabilities.MaxImageExtent.Width, surfaceCapabilities.MaxImageExtent.Height))
-		table.AddRow("Usage flags", fmt.Sprintf("%02x",
-			surfaceCapabilities.SupportedUsageFlags))
-		table.AddRow("Current transform", fmt.Sprintf("%02x",
-			surfaceCapabilities.CurrentTransform))
-		table.AddRow("Allowed transforms", fmt.Sprintf("%02x",
-			surfaceCapabilities.SupportedTransforms))
-		var formatCount uint32
-		vk.GetPhysicalDeviceSurfaceFormats(v.gpuDevices[0], v.surface, &formatCount, nil)
-		table.AddRow("Surface formats", fmt.Sprintf("%d of %d", formatCount, vk.FormatRangeSize))
+			s.MinImageExtent.Width, s.MinImageExtent.Height,
+			s.MaxImageExtent.Width, s.MaxImageExtent.Height))
+		table.AddRow("Usage flags", joinFlags(s.SupportedUsageFlags))
+		table.AddRow("Current transform", s.CurrentTransform)
+		table.AddRow("Allowed transforms", joinFlags(s.SupportedTransforms))
+		table.AddRow("Composite alpha", joinFlags(s.SupportedCompositeAlpha))
+		table.AddRow("Surface formats", fmt.Sprintf("%d of %d", len(s.Formats), vk.FormatRangeSize))
+		for i, format := range s.Formats {
+			table.AddRow(i+1, fmt.Sprintf("%s, %s", format.Format, format.ColorSpace))
+		}
+		table.AddRow("Present modes", joinFlags(s.PresentModes))
+	}
+
+	if dp := d.Driver; dp != nil {
+		table.AddRow("Driver ID", dp.DriverID)
+		table.AddRow("Driver Name", dp.DriverName)
+		table.AddRow("Driver Info", dp.DriverInfo)
+		table.AddRow("Conformance Version", dp.ConformanceVersion)
+	}
+
+	table.AddSeparator()
+	table.AddRow("DEVICE LIMITS", "")
+	addFieldRows(table, d.Limits)
+
+	table.AddSeparator()
+	table.AddRow("SPARSE PROPERTIES", "")
+	addFieldRows(table, d.SparseProperties)
+
+	if sp := d.Subgroup; sp != nil {
+		table.AddSeparator()
+		table.AddRow("SUBGROUP PROPERTIES", "")
+		table.AddRow("SubgroupSize", sp.SubgroupSize)
+		table.AddRow("SupportedStages", joinFlags(sp.SupportedStages))
+		table.AddRow("SupportedOperations", joinFlags(sp.SupportedOperations))
+		table.AddRow("QuadOperationsInAllStages", sp.QuadOperationsInAllStages)
+	}
+	if d.Vulkan11Features != nil {
+		table.AddSeparator()
+		table.AddRow("VULKAN 1.1 FEATURES", "")
+		addFieldRows(table, *d.Vulkan11Features)
+	}
+	if d.Vulkan12Features != nil {
+		table.AddSeparator()
+		table.AddRow("VULKAN 1.2 FEATURES", "")
+		addFieldRows(table, *d.Vulkan12Features)
+	}
+	if d.Vulkan13Features != nil {
+		table.AddSeparator()
+		table.AddRow("VULKAN 1.3 FEATURES", "")
+		addFieldRows(table, *d.Vulkan13Features)
+	}
+	if di := d.DescriptorIndexing; di != nil {
 		table.AddSeparator()
+		table.AddRow("DESCRIPTOR INDEXING", "")
+		addFieldRows(table, di.Features)
+		addFieldRows(table, di.Properties)
 	}
 
-	table.AddRow("INSTANCE EXTENSIONS", "")
-	instanceExt := getInstanceExtensions()
-	for i, extName := range instanceExt {
-		table.AddRow(i+1, extName)
+	table.AddSeparator()
+	table.AddRow("MEMORY HEAPS", "")
+	for i, heap := range d.MemoryHeaps {
+		table.AddRow(fmt.Sprintf("Heap #%d", i), fmt.Sprintf("%s, %s",
+			formatSize(heap.Size), joinFlags(heap.Flags)))
 	}
 
 	table.AddSeparator()
-	table.AddRow("DEVICE EXTENSIONS", "")
-	deviceExt := getDeviceExtensions(v.gpuDevices[0])
-	for i, extName := range deviceExt {
-		table.AddRow(i+1, extName)
+	table.AddRow("MEMORY TYPES", "")
+	for i, memType := range d.MemoryTypes {
+		table.AddRow(fmt.Sprintf("Type #%d", i), fmt.Sprintf("heap %d, %s",
+			memType.HeapIndex, joinFlags(memType.PropertyFlags)))
 	}
 
-	instanceLayers := getInstanceLayers()
-	if len(instanceLayers) > 0 {
-		table.AddSeparator()
-		table.AddRow("INSTANCE LAYERS")
-		for i, layerName := range instanceLayers {
-			table.AddRow(i+1, layerName)
+	table.AddSeparator()
+	table.AddRow("QUEUE FAMILIES", "")
+	for i, family := range d.QueueFamilies {
+		g := family.MinImageTransferGranularity
+		table.AddRow(fmt.Sprintf("Family #%d", i), joinFlags(family.QueueFlags))
+		table.AddRow("  Queue count", family.QueueCount)
+		table.AddRow("  Timestamp valid bits", family.TimestampValidBits)
+		table.AddRow("  Min image transfer granularity",
+			fmt.Sprintf("%dx%dx%d", g.Width, g.Height, g.Depth))
+		if family.SupportsPresent != nil {
+			table.AddRow("  Present support", *family.SupportsPresent)
 		}
 	}
 
-	deviceLayers := getDeviceLayers(v.gpuDevices[0])
-	if len(deviceLayers) > 0 {
+	table.AddSeparator()
+	table.AddRow("FORMATS", fmt.Sprintf("%d of %d", len(d.Formats), vk.FormatRangeSize))
+	for _, format := range d.Formats {
+		table.AddRow(format.Format, "")
+		table.AddRow("  Linear tiling", joinFlags(format.LinearTilingFeatures))
+		table.AddRow("  Optimal tiling", joinFlags(format.OptimalTilingFeatures))
+		table.AddRow("  Buffer", joinFlags(format.BufferFeatures))
+	}
+
+	table.AddSeparator()
+	table.AddRow("DEVICE EXTENSIONS", "")
+	addExtensionRows(table, d.Extensions)
+
+	if len(d.Layers) > 0 {
 		table.AddSeparator()
 		table.AddRow("DEVICE LAYERS")
-		for i, layerName := range deviceLayers {
-			table.AddRow(i+1, layerName)
+		addLayerRows(table, d.Layers)
+	}
+}
+
+func addExtensionRows(table *tablewriter.Table, extensions []Extension) {
+	for i, ext := range extensions {
+		table.AddRow(i+1, fmt.Sprintf("%s (rev %d)", ext.Name, ext.SpecVersion))
+	}
+}
+
+func addLayerRows(table *tablewriter.Table, layers []Layer) {
+	for i, layer := range layers {
+		table.AddRow(i+1, fmt.Sprintf("%s %s (impl %d)",
+			layer.Name, vk.Version(layer.SpecVersion), layer.ImplementationVersion))
+		table.AddRow("", layer.Description)
+		for _, ext := range layer.Extensions {
+			table.AddRow("", fmt.Sprintf("  %s (rev %d)", ext.Name, ext.SpecVersion))
 		}
 	}
+}
 
-	fmt.Println("\n\n" + table.Render())
+// addFieldRows adds a row per field of the struct, named after the field.
+func addFieldRows(table *tablewriter.Table, v interface{}) {
+	rv := reflect.ValueOf(v)
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		table.AddRow(rt.Field(i).Name, fmt.Sprintf("%v", rv.Field(i).Interface()))
+	}
 }
 
 func physicalDeviceType(dev vk.PhysicalDeviceType) string {
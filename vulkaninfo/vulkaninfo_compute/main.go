@@ -1,10 +1,21 @@
 package main
 
 import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
 	"github.com/vulkan-go/demos/vulkaninfo"
 	vk "github.com/vulkan-go/vulkan"
 )
 
+var (
+	deviceIdx    = flag.Int("device", vulkaninfo.AllDevices, "Index of the physical device to report, all devices by default.")
+	outputFormat = flag.String("format", "table", "Output format: table, json or html.")
+	profilePath  = flag.String("profile", "", "Path to a JSON profile to check the devices against.")
+)
+
 var appInfo = &vk.ApplicationInfo{
 	SType:              vk.StructureTypeApplicationInfo,
 	ApiVersion:         vk.MakeVersion(1, 0, 0),
@@ -14,12 +25,101 @@ var appInfo = &vk.ApplicationInfo{
 }
 
 func main() {
+	flag.Parse()
+	switch flag.Arg(0) {
+	case "diff":
+		os.Exit(diff(flag.Args()[1:]))
+	case "aggregate":
+		os.Exit(aggregate(flag.Args()[1:]))
+	}
+	switch *outputFormat {
+	case "table", "json", "html":
+	default:
+		log.Fatalf("unknown output format %q, expected table, json or html", *outputFormat)
+	}
+
 	orPanic(vk.SetDefaultGetInstanceProcAddr())
 	orPanic(vk.Init())
-	vkDevice, err := vulkaninfo.NewVulkanDevice(appInfo, 0)
+	appInfo.ApiVersion = vulkaninfo.InstanceVersion()
+	vkDevice, err := vulkaninfo.NewVulkanDevice(appInfo, nil, nil)
 	orPanic(err)
-	vulkaninfo.PrintInfo(vkDevice)
+	report, err := vulkaninfo.NewReport(vkDevice, *deviceIdx)
 	vkDevice.Destroy()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if len(*profilePath) > 0 {
+		ok, err := report.CheckProfileFile(os.Stdout, *profilePath)
+		switch {
+		case err != nil:
+			log.Println(err)
+			os.Exit(2)
+		case !ok:
+			// none of the devices qualifies
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	switch *outputFormat {
+	case "json":
+		err = vulkaninfo.WriteJSON(os.Stdout, report)
+	case "html":
+		err = vulkaninfo.WriteHTML(os.Stdout, report)
+	default:
+		err = vulkaninfo.FprintReport(os.Stdout, report)
+	}
+	orPanic(err)
+}
+
+// diff prints the differences between two saved JSON reports,
+// the exit code is 1 if there are any, like diff(1) does.
+func diff(args []string) int {
+	if len(args) != 2 {
+		log.Println("usage: vulkaninfo_compute diff a.json b.json")
+		return 2
+	}
+	a, err := vulkaninfo.LoadReport(args[0])
+	if err != nil {
+		log.Println(err)
+		return 2
+	}
+	b, err := vulkaninfo.LoadReport(args[1])
+	if err != nil {
+		log.Println(err)
+		return 2
+	}
+	diffs := vulkaninfo.DiffReports(a, b)
+	vulkaninfo.PrintDiff(os.Stdout, diffs)
+	if len(diffs) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// aggregate prints the statistics over a directory of saved JSON reports.
+func aggregate(args []string) int {
+	if len(args) != 1 {
+		log.Println("usage: vulkaninfo_compute [-format table|json] aggregate dir")
+		return 2
+	}
+	reports, err := vulkaninfo.LoadReports(args[0])
+	if err != nil {
+		log.Println(err)
+		return 2
+	}
+	stats := vulkaninfo.AggregateReports(reports)
+	if *outputFormat == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(stats)
+	} else {
+		err = vulkaninfo.FprintAggregate(os.Stdout, stats)
+	}
+	if err != nil {
+		log.Println(err)
+		return 2
+	}
+	return 0
 }
 
 func orPanic(err interface{}) {
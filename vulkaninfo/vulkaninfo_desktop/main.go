@@ -1,11 +1,21 @@
 package main
 
 import (
+	"flag"
+	"log"
+	"os"
+
 	"github.com/vulkan-go/demos/vulkaninfo"
 	"github.com/go-gl/glfw/v3.3/glfw"
 	vk "github.com/vulkan-go/vulkan"
 )
 
+var (
+	deviceIdx    = flag.Int("device", vulkaninfo.AllDevices, "Index of the physical device to report, all devices by default.")
+	outputFormat = flag.String("format", "table", "Output format: table, json or html.")
+	profilePath  = flag.String("profile", "", "Path to a JSON profile to check the devices against.")
+)
+
 var appInfo = &vk.ApplicationInfo{
 	SType:              vk.StructureTypeApplicationInfo,
 	ApiVersion:         vk.MakeVersion(1, 0, 0),
@@ -15,19 +25,59 @@ var appInfo = &vk.ApplicationInfo{
 }
 
 func main() {
+	flag.Parse()
+	switch *outputFormat {
+	case "table", "json", "html":
+	default:
+		log.Fatalf("unknown output format %q, expected table, json or html", *outputFormat)
+	}
+
 	orPanic(glfw.Init())
 	vk.SetGetInstanceProcAddr(glfw.GetVulkanGetInstanceProcAddress())
 	orPanic(vk.Init())
+	appInfo.ApiVersion = vulkaninfo.InstanceVersion()
 
 	glfw.WindowHint(glfw.ClientAPI, glfw.NoAPI)
 	window, err := glfw.CreateWindow(640, 480, "Vulkan Info", nil, nil)
 	orPanic(err)
 	defer window.Destroy()
 
-	vkDevice, err := vulkaninfo.NewVulkanDevice(appInfo, uintptr(window.Handle()))
+	createSurface := func(instance interface{}) uintptr {
+		surface, err := window.CreateWindowSurface(instance, nil)
+		orPanic(err)
+		return surface
+	}
+
+	vkDevice, err := vulkaninfo.NewVulkanDevice(appInfo,
+		window.GetRequiredInstanceExtensions(),
+		createSurface)
 	orPanic(err)
-	vulkaninfo.PrintInfo(vkDevice)
+	report, err := vulkaninfo.NewReport(vkDevice, *deviceIdx)
 	vkDevice.Destroy()
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if len(*profilePath) > 0 {
+		ok, err := report.CheckProfileFile(os.Stdout, *profilePath)
+		switch {
+		case err != nil:
+			log.Println(err)
+			os.Exit(2)
+		case !ok:
+			// none of the devices qualifies
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+	switch *outputFormat {
+	case "json":
+		err = vulkaninfo.WriteJSON(os.Stdout, report)
+	case "html":
+		err = vulkaninfo.WriteHTML(os.Stdout, report)
+	default:
+		err = vulkaninfo.FprintReport(os.Stdout, report)
+	}
+	orPanic(err)
 }
 
 func orPanic(err interface{}) {
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"unsafe"
+
 	"github.com/vulkan-go/demos/vulkaninfo"
 	vk "github.com/vulkan-go/vulkan"
 	"github.com/xlab/catcher"
@@ -31,9 +33,18 @@ func main() {
 				case app.ViewDidLoad:
 					err := vk.Init()
 					orPanic(err)
-					vkDevice, err = vulkaninfo.NewVulkanDevice(appInfo, event.View)
+					appInfo.ApiVersion = vulkaninfo.InstanceVersion()
+					window := event.View
+					createSurface := func(instance interface{}) uintptr {
+						var surface vk.Surface
+						ret := vk.CreateWindowSurface(instance.(vk.Instance), window, nil, &surface)
+						orPanic(ret)
+						return uintptr(unsafe.Pointer(&surface))
+					}
+					vkDevice, err = vulkaninfo.NewVulkanDevice(appInfo,
+						vk.GetRequiredInstanceExtensions(), createSurface)
 					orPanic(err)
-					vulkaninfo.PrintInfo(vkDevice)
+					vulkaninfo.PrintInfo(vkDevice, vulkaninfo.AllDevices)
 				case app.WillTerminate:
 					vkDevice.Destroy()
 				}